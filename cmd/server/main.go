@@ -1,14 +1,33 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"flag"
+	"fmt"
 	"html/template"
-	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"file-board/internal/config"
 	"file-board/internal/database"
 	"file-board/internal/handlers"
+	"file-board/internal/jobs"
+	"file-board/internal/lifecycle"
+	"file-board/internal/logging"
+	"file-board/internal/metrics"
 	"file-board/internal/middleware"
+	"file-board/internal/models"
+	"file-board/internal/proxyproto"
+	"file-board/internal/scanner"
 	"file-board/internal/services"
 
 	"github.com/gin-contrib/sessions"
@@ -17,74 +36,351 @@ import (
 )
 
 func main() {
-	// 설정 로드
-	cfg := config.Load()
+	// 설정 로드 (명령줄 > 환경 변수 > 설정 파일 > 기본값)
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	// 구조화 로그 설정 (LOG_FORMAT, LOG_LEVEL)
+	if _, err := logging.Setup(cfg.Log, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "로그 설정 오류:", err)
+		os.Exit(1)
+	}
+	// gin 디버그 출력(라우트 목록 등)도 같은 로거로 기록
+	gin.DebugPrintFunc = func(format string, values ...interface{}) {
+		slog.Debug(strings.TrimSpace(fmt.Sprintf(format, values...)))
+	}
+	slog.Info("설정 로드", "config", cfg)
+
+	// 프록시 헤더(Forwarded 등)와 PROXY protocol 헤더를 신뢰할 대역
+	trustedProxies, err := middleware.ParseTrustedProxies(cfg.Server.TrustedProxies)
+	if err != nil {
+		fatal("TRUSTED_PROXIES 설정 오류", err)
+	}
 
 	// 데이터베이스 연결
 	db, err := database.New(cfg)
 	if err != nil {
-		log.Fatal("데이터베이스 연결 실패:", err)
+		fatal("데이터베이스 연결 실패", err)
+	}
+
+	// 스키마 마이그레이션 적용
+	if err := db.Migrate(); err != nil {
+		fatal("데이터베이스 마이그레이션 실패", err)
+	}
+
+	// 악성코드 검사기 초기화 (CLAMD_ADDRESS 설정 시)
+	var fileScanner *scanner.Scanner
+	if cfg.Scanner.ClamdAddress != "" {
+		fileScanner, err = scanner.New(cfg.Scanner.ClamdAddress, cfg.Scanner.Timeout)
+		if err != nil {
+			fatal("악성코드 검사기 설정 오류", err)
+		}
+		if err := fileScanner.Ping(); err != nil {
+			slog.Warn("clamd 응답 없음, 업로드 시 검사 오류로 기록됩니다", "address", fileScanner.Address(), "error", err)
+		} else {
+			slog.Info("clamd 연결 확인", "address", fileScanner.Address())
+		}
 	}
-	defer db.Close()
+
+	// 백그라운드 작업 큐
+	jobQueue := jobs.NewQueue(db.GetConnection(), cfg.Jobs)
+
+	// Prometheus 지표
+	appMetrics := metrics.New()
+	appMetrics.RegisterDBStats(db.GetConnection())
 
 	// 서비스 초기화
-	postService := services.NewPostService(db.GetConnection(), cfg)
+	settingsService := services.NewSettingsService(db.GetConnection(), cfg)
+	if _, err := settingsService.Reload(context.Background()); err != nil {
+		slog.Error("저장된 운영 설정을 적용할 수 없어 기본값을 사용합니다", "error", err)
+	}
+	postService := services.NewPostService(db.GetConnection(), cfg, settingsService, fileScanner, jobQueue, appMetrics)
+	commentService := services.NewCommentService(db.GetConnection(), cfg, settingsService)
+	banService := services.NewBanService(db.GetConnection())
+	boardService := services.NewBoardService(db.GetConnection(), cfg, settingsService)
+	healthService := services.NewHealthService(db, cfg)
+
+	// 작업 처리 함수 등록 후 워커 시작
+	jobQueue.RegisterWithFailure(services.JobScanFile, postService.HandleScanFileJob, postService.FailScanFileJob)
+	jobQueue.Register(services.JobThumbnail, postService.HandleThumbnailJob)
+	jobQueue.Register(services.JobArchiveIndex, postService.HandleArchiveIndexJob)
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	jobQueue.Start(workerCtx)
+
+	// 보관 기간이 지난 게시글 정리 (운영 설정의 보관 기간이 0이면 아무것도 하지 않음)
+	var background sync.WaitGroup
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		postService.RunRetention(workerCtx, services.RetentionInterval)
+	}()
 
 	// 핸들러 초기화
-	userHandler := handlers.NewHandler(postService, cfg)
-	adminHandler := handlers.NewAdminHandler(postService, cfg)
+	userHandler := handlers.NewHandler(postService, commentService, boardService, settingsService, cfg)
+	adminHandler := handlers.NewAdminHandler(postService, commentService, banService, boardService, settingsService, jobQueue, cfg)
+	healthHandler := handlers.NewHealthHandler(healthService)
+
+	// 저장소 사용량, 작업 대기열 지표 등록
+	registerServiceMetrics(appMetrics, postService, jobQueue)
 
-	// 사용자 서버 시작 (고루틴)
-	go startUserServer(userHandler, cfg)
+	// 사용자/관리자 서버 (지표 전용 서버는 METRICS_PORT 설정 시, 아니면 관리자 서버에서 제공)
+	servers := lifecycle.New(cfg.Server.ShutdownTimeout)
+	servers.Add("user", &http.Server{Handler: newUserServer(userHandler, healthHandler, banService, settingsService, appMetrics, trustedProxies, cfg)}, listen(cfg.Server.Port, trustedProxies, cfg))
+	servers.Add("admin", &http.Server{Handler: newAdminServer(adminHandler, userHandler.IncludingDeleted(), healthHandler, settingsService, appMetrics, trustedProxies, cfg)}, listen(cfg.Server.AdminPort, trustedProxies, cfg))
+	if cfg.Metrics.Port != "" {
+		metricsListener, err := net.Listen("tcp", ":"+cfg.Metrics.Port)
+		if err != nil {
+			fatal("지표 서버 시작 실패", err)
+		}
+		servers.Add("metrics", &http.Server{Handler: newMetricsServer(appMetrics, cfg)}, metricsListener)
+	}
+
+	// SIGHUP 수신 시 운영 설정 다시 읽기
+	go reloadSettingsOnSignal(settingsService)
+
+	// SIGINT/SIGTERM 수신 시 새 연결을 받지 않고 진행 중인 요청을 마친 뒤 종료
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	runErr := servers.Run(ctx)
+	stop() // 이후 신호는 기본 동작(즉시 종료)
 
-	// 관리자 서버 시작
-	startAdminServer(adminHandler, userHandler, cfg)
+	// 서버 종료 후 워커와 보관 기간 정리 중지 (제한 시간 안에 끝나지 않은 작업은 가시성 타임아웃 이후 다시 처리됨)
+	stopWorkers()
+	waitWorkers(jobQueue, &background, cfg.Server.ShutdownTimeout)
+
+	db.Close()
+	if runErr != nil {
+		fatal("서버 종료 중 오류", runErr)
+	}
+	slog.Info("종료 완료")
 }
 
-func startUserServer(handler *handlers.Handler, cfg *config.Config) {
-	r := gin.Default()
+// reloadSettingsOnSignal SIGHUP을 받을 때마다 settings 테이블을 다시 읽어 적용
+func reloadSettingsOnSignal(settingsService *services.SettingsService) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	for range hup {
+		if _, err := settingsService.Reload(context.Background()); err != nil {
+			slog.Error("운영 설정 다시 읽기 실패, 기존 설정 유지", "error", err)
+		}
+	}
+}
 
-	// 정적 파일 제공
-	r.Static("/static", "./web/static")
+// waitWorkers 작업 워커와 백그라운드 고루틴 종료를 제한 시간까지 대기 (DB 연결을 닫기 전에 호출)
+func waitWorkers(jobQueue *jobs.Queue, background *sync.WaitGroup, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		jobQueue.Wait()
+		background.Wait()
+		close(done)
+	}()
 
-	// 템플릿 함수 등록
-	r.SetFuncMap(template.FuncMap{
-		"kstTime": func(t time.Time) string {
-			loc, _ := time.LoadLocation("Asia/Seoul")
-			return t.In(loc).Format("2006-01-02 15:04")
-		},
-		"kstTimeISO": func(t time.Time) string {
-			loc, _ := time.LoadLocation("Asia/Seoul")
-			return t.In(loc).Format("2006-01-02T15:04:05Z07:00")
-		},
-	})
+	select {
+	case <-done:
+		slog.Info("작업 워커 종료")
+	case <-time.After(timeout):
+		slog.Warn("작업 워커 종료 제한 시간 초과", "timeout", timeout)
+	}
+}
 
-	// HTML 템플릿 로드
-	r.LoadHTMLGlob("web/templates/*")
+// newUserServer 사용자 서버 라우팅
+func newUserServer(handler *handlers.Handler, healthHandler *handlers.HealthHandler, banService *services.BanService, settingsService *services.SettingsService, m *metrics.Metrics, trustedProxies middleware.TrustedProxies, cfg *config.Config) *gin.Engine {
+	r := newEngine(cfg, m, settingsService, trustedProxies, "user")
+
+	// 상태 확인 (컨테이너 오케스트레이터, 로드 밸런서용)
+	r.GET("/healthz", healthHandler.HealthzHandler)
+	r.GET("/readyz", healthHandler.ReadyzHandler)
 
 	// 라우팅 설정
 	r.GET("/", handler.IndexHandler)
-	r.POST("/upload/file", handler.UploadFileHandler)
-	r.POST("/upload/message", handler.UploadMessageHandler)
+	r.GET("/boards/:slug", handler.BoardHandler)
+	rejectBanned := middleware.RejectBannedIP(banService)
+	requirePosting := middleware.RequireAnonymousPosting(settingsService)
+	r.POST("/upload/file", requirePosting, rejectBanned, handler.UploadFileHandler)
+	r.POST("/upload/message", requirePosting, rejectBanned, handler.UploadMessageHandler)
 	r.GET("/download/:id", handler.DownloadFileHandler)
+	r.GET("/download/:id/:n", handler.DownloadFileHandler)
+	r.GET("/download/:id/:n/entries/:entry", handler.ArchiveEntryHandler)
+	r.GET("/thumbnails/:hash", handler.ThumbnailHandler)
+	r.GET("/view/:id", handler.ViewFileHandler)
+	r.GET("/view/:id/:n", handler.ViewFileHandler)
+	r.GET("/raw/:id", handler.RawFileHandler)
+	r.GET("/raw/:id/:n", handler.RawFileHandler)
+	r.GET("/archive", handler.ArchiveHandler)
+	r.GET("/archive/:id", handler.ArchiveHandler)
+	r.POST("/preview/markdown", handler.MarkdownPreviewHandler)
+	r.GET("/posts/:id", handler.PostHandler)
+	r.GET("/posts/:id/comments", handler.ListCommentsHandler)
+	r.POST("/posts/:id/comments", requirePosting, rejectBanned, handler.CreateCommentHandler)
 
-	log.Printf("사용자 서버 시작: http://localhost:%s", cfg.Server.Port)
-	if err := r.Run(":" + cfg.Server.Port); err != nil {
-		log.Fatal("사용자 서버 시작 실패:", err)
-	}
+	return r
 }
 
-func startAdminServer(adminHandler *handlers.AdminHandler, userHandler *handlers.Handler, cfg *config.Config) {
-	r := gin.Default()
+// newAdminServer 관리자 서버 라우팅
+func newAdminServer(adminHandler *handlers.AdminHandler, userHandler *handlers.Handler, healthHandler *handlers.HealthHandler, settingsService *services.SettingsService, m *metrics.Metrics, trustedProxies middleware.TrustedProxies, cfg *config.Config) *gin.Engine {
+	r := newEngine(cfg, m, settingsService, trustedProxies, "admin")
 
 	// 세션 설정
-	store := cookie.NewStore([]byte("secret-admin-session-key-change-this-in-production"))
+	store := cookie.NewStore(sessionSecret(cfg))
 	r.Use(sessions.Sessions("admin-session", store))
 
+	// 로그인/로그아웃 라우팅 (인증 미들웨어 적용 전)
+	r.Any("/login", middleware.HandleAdminLogin(cfg.Server.AdminPassword))
+	r.GET("/logout", middleware.HandleAdminLogout())
+
+	// 상태 확인 (로그인 없이 접근 가능, 관리자로 로그인하면 항목별 결과 표시)
+	r.GET("/healthz", healthHandler.HealthzHandler)
+	r.GET("/readyz", healthHandler.ReadyzHandler)
+
+	// 지표 경로 (토큰이 있으면 토큰으로, 없으면 관리자 로그인으로 보호)
+	if cfg.Metrics.Port == "" && cfg.Metrics.Token != "" {
+		r.GET(cfg.Metrics.Path, middleware.RequireMetricsToken(cfg.Metrics.Token), gin.WrapH(m.Registry.Handler()))
+	}
+
+	// 관리자 인증이 필요한 라우팅
+	adminGroup := r.Group("/")
+	adminGroup.Use(middleware.RequireAdminAuth())
+	{
+		adminGroup.GET("/", adminHandler.IndexHandler)
+		adminGroup.DELETE("/delete/:id", adminHandler.DeletePostHandler)
+		adminGroup.POST("/restore/:id", adminHandler.RestorePostHandler)
+		adminGroup.POST("/posts/bulk", adminHandler.BulkPostsHandler)
+		adminGroup.POST("/posts/:id/pin", adminHandler.PinPostHandler)
+		adminGroup.DELETE("/posts/:id/pin", adminHandler.UnpinPostHandler)
+		adminGroup.PUT("/posts/:id", adminHandler.EditPostHandler)
+		adminGroup.GET("/posts/:id/revisions", adminHandler.ListRevisionsHandler)
+		adminGroup.POST("/posts/:id/revisions/:rev/revert", adminHandler.RevertPostHandler)
+		adminGroup.GET("/stats", adminHandler.GetStatsHandler)
+		adminGroup.GET("/bans", adminHandler.ListBansHandler)
+		adminGroup.POST("/bans", adminHandler.CreateBanHandler)
+		adminGroup.DELETE("/bans/:id", adminHandler.DeleteBanHandler)
+		adminGroup.GET("/boards", adminHandler.ListBoardsHandler)
+		adminGroup.POST("/boards", adminHandler.CreateBoardHandler)
+		adminGroup.PUT("/boards/:id", adminHandler.UpdateBoardHandler)
+		adminGroup.DELETE("/boards/:id", adminHandler.DeleteBoardHandler)
+		adminGroup.GET("/posts/:id/comments", adminHandler.ListCommentsHandler)
+		adminGroup.DELETE("/comments/:id", adminHandler.DeleteCommentHandler)
+		adminGroup.POST("/comments/:id/restore", adminHandler.RestoreCommentHandler)
+		adminGroup.GET("/settings", adminHandler.SettingsHandler)
+		adminGroup.PUT("/settings", adminHandler.UpdateSettingsHandler)
+		adminGroup.POST("/settings/reload", adminHandler.ReloadSettingsHandler)
+		adminGroup.GET("/jobs", adminHandler.JobsHandler)
+		adminGroup.POST("/jobs/:id/retry", adminHandler.RetryJobHandler)
+		adminGroup.GET("/download/:id", userHandler.DownloadFileHandler) // 동일한 다운로드 핸들러 재사용 (삭제된 게시글 포함)
+		adminGroup.GET("/download/:id/:n", userHandler.DownloadFileHandler)
+		adminGroup.GET("/download/:id/:n/entries/:entry", userHandler.ArchiveEntryHandler)
+		adminGroup.GET("/thumbnails/:hash", userHandler.ThumbnailHandler)
+		adminGroup.GET("/view/:id", userHandler.ViewFileHandler)
+		adminGroup.GET("/view/:id/:n", userHandler.ViewFileHandler)
+		adminGroup.GET("/raw/:id", userHandler.RawFileHandler)
+		adminGroup.GET("/raw/:id/:n", userHandler.RawFileHandler)
+		adminGroup.GET("/archive", adminHandler.ArchiveHandler)
+		adminGroup.GET("/archive/:id", adminHandler.ArchiveHandler)
+		adminGroup.GET("/export", adminHandler.ExportHandler)
+		if cfg.Metrics.Port == "" && cfg.Metrics.Token == "" {
+			adminGroup.GET(cfg.Metrics.Path, gin.WrapH(m.Registry.Handler()))
+		}
+	}
+
+	return r
+}
+
+// newMetricsServer 지표 전용 서버 (내부망 수집용, METRICS_TOKEN 설정 시 토큰 필요)
+func newMetricsServer(m *metrics.Metrics, cfg *config.Config) *gin.Engine {
+	r := gin.New()
+	r.Use(middleware.Recovery())
+	if cfg.Metrics.Token != "" {
+		r.Use(middleware.RequireMetricsToken(cfg.Metrics.Token))
+	}
+	r.GET(cfg.Metrics.Path, gin.WrapH(m.Registry.Handler()))
+	return r
+}
+
+// 지표 수집 쿼리 제한 시간 (Prometheus 기본 scrape_timeout 10초보다 짧게)
+const metricsQueryTimeout = 5 * time.Second
+
+// 저장소 사용량 캐시 시간 (같은 수집에서 여러 지표가 공유하고, 잦은 수집에도 집계 쿼리를 반복하지 않음)
+const storageUsageCacheTTL = 10 * time.Second
+
+// registerServiceMetrics 조회 시점에 DB에서 계산하는 지표 등록
+func registerServiceMetrics(m *metrics.Metrics, postService *services.PostService, jobQueue *jobs.Queue) {
+	var (
+		storageMu       sync.Mutex
+		storageUsage    *models.StorageUsage
+		storageCachedAt time.Time
+	)
+	storage := func(value func(*models.StorageUsage) []metrics.Sample) func() []metrics.Sample {
+		return func() []metrics.Sample {
+			storageMu.Lock()
+			defer storageMu.Unlock()
+
+			if storageUsage == nil || time.Since(storageCachedAt) > storageUsageCacheTTL {
+				ctx, cancel := context.WithTimeout(context.Background(), metricsQueryTimeout)
+				usage, err := postService.GetStorageUsage(ctx)
+				cancel()
+				if err != nil {
+					slog.Error("지표 수집 실패", "error", err)
+					return nil
+				}
+				storageUsage, storageCachedAt = usage, time.Now()
+			}
+			return value(storageUsage)
+		}
+	}
+	m.Registry.NewGaugeFunc("board_storage_files", "저장된 고유 파일 수", nil,
+		storage(func(u *models.StorageUsage) []metrics.Sample { return metrics.Value(float64(u.Files)) }))
+	m.Registry.NewGaugeFunc("board_storage_bytes",
+		"파일 크기 합계 (stored: 실제 저장, referenced: 게시글이 참조하는 크기로 중복 포함)", []string{"kind"},
+		storage(func(u *models.StorageUsage) []metrics.Sample {
+			return []metrics.Sample{
+				{Labels: []string{"stored"}, Value: float64(u.Bytes)},
+				{Labels: []string{"referenced"}, Value: float64(u.ReferencedBytes)},
+			}
+		}))
+
+	m.Registry.NewGaugeFunc("board_jobs", "상태별 백그라운드 작업 수 (queued가 대기열 길이)", []string{"status"},
+		func() []metrics.Sample {
+			ctx, cancel := context.WithTimeout(context.Background(), metricsQueryTimeout)
+			defer cancel()
+			stats, err := jobQueue.GetStats(ctx)
+			if err != nil {
+				slog.Error("지표 수집 실패", "error", err)
+				return nil
+			}
+			return []metrics.Sample{
+				{Labels: []string{models.JobStatusQueued}, Value: float64(stats.Queued)},
+				{Labels: []string{models.JobStatusRunning}, Value: float64(stats.Running)},
+				{Labels: []string{models.JobStatusDone}, Value: float64(stats.Done)},
+				{Labels: []string{models.JobStatusFailed}, Value: float64(stats.Failed)},
+			}
+		})
+}
+
+// newEngine 사용자/관리자 서버 공통 gin 엔진 생성 (요청 ID, 지표, 로그, 프록시 설정, 정적 파일, 템플릿)
+func newEngine(cfg *config.Config, m *metrics.Metrics, settingsService *services.SettingsService, trustedProxies middleware.TrustedProxies, server string) *gin.Engine {
+	r := gin.New()
+
+	// 요청 ID 부여 후 정적 파일을 포함한 모든 라우트의 요청 지표와 로그 기록
+	r.Use(middleware.RequestID())
+	r.Use(middleware.RequestMetrics(m, server))
+	r.Use(middleware.RequestLogger(server))
+	r.Use(middleware.Recovery())
+
+	// 클라이언트 IP 복원은 ClientIP 미들웨어가 전담 (gin 자체 헤더 신뢰 비활성화)
+	if err := r.SetTrustedProxies(nil); err != nil {
+		fatal("프록시 설정 실패", err)
+	}
+	r.Use(middleware.ClientIP(trustedProxies))
+
 	// 정적 파일 제공
 	r.Static("/static", "./web/static")
 
-	// 템플릿 함수 등록 (관리자도 동일)
+	// 템플릿 함수 등록
 	r.SetFuncMap(template.FuncMap{
 		"kstTime": func(t time.Time) string {
 			loc, _ := time.LoadLocation("Asia/Seoul")
@@ -94,28 +390,45 @@ func startAdminServer(adminHandler *handlers.AdminHandler, userHandler *handlers
 			loc, _ := time.LoadLocation("Asia/Seoul")
 			return t.In(loc).Format("2006-01-02T15:04:05Z07:00")
 		},
+		// 현재 운영 설정 (게시판 제목, 안내 문구 등 - 렌더링할 때마다 최신 값 사용)
+		"site": settingsService.Current,
 	})
 
 	// HTML 템플릿 로드
 	r.LoadHTMLGlob("web/templates/*")
 
-	// 로그인/로그아웃 라우팅 (인증 미들웨어 적용 전)
-	r.Any("/login", middleware.HandleAdminLogin(cfg.Server.AdminPassword))
-	r.GET("/logout", middleware.HandleAdminLogout())
+	return r
+}
 
-	// 관리자 인증이 필요한 라우팅
-	adminGroup := r.Group("/")
-	adminGroup.Use(middleware.RequireAdminAuth())
-	{
-		adminGroup.GET("/", adminHandler.IndexHandler)
-		adminGroup.DELETE("/delete/:id", adminHandler.DeletePostHandler)
-		adminGroup.POST("/restore/:id", adminHandler.RestorePostHandler)
-		adminGroup.GET("/stats", adminHandler.GetStatsHandler)
-		adminGroup.GET("/download/:id", userHandler.DownloadFileHandler) // 동일한 다운로드 핸들러 재사용
+// listen 지정 포트 리스너 생성 (설정 시 신뢰할 프록시의 PROXY protocol 헤더만 해석하는 리스너 사용)
+func listen(port string, trustedProxies middleware.TrustedProxies, cfg *config.Config) net.Listener {
+	listener, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		fatal("포트 열기 실패 ("+port+")", err)
 	}
 
-	log.Printf("관리자 서버 시작: http://localhost:%s", cfg.Server.AdminPort)
-	if err := r.Run(":" + cfg.Server.AdminPort); err != nil {
-		log.Fatal("관리자 서버 시작 실패:", err)
+	if cfg.Server.ProxyProtocol {
+		// 목록이 비어 있으면 어떤 상대의 헤더도 신뢰하지 않음 (설정 검사에서도 거부)
+		listener = proxyproto.NewListener(listener, trustedProxies.Contains)
 	}
+	return listener
+}
+
+// sessionSecret 관리자 세션 서명 키 (SESSION_SECRET 미설정 시 실행마다 새로 생성, 운영 모드에서는 설정 검사에서 거부됨)
+func sessionSecret(cfg *config.Config) []byte {
+	if cfg.Server.SessionSecret != "" {
+		return []byte(cfg.Server.SessionSecret)
+	}
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		fatal("세션 키 생성 실패", err)
+	}
+	slog.Warn("SESSION_SECRET이 설정되지 않아 임시 세션 키를 사용합니다 (재시작하면 관리자 로그인이 해제됩니다)")
+	return secret
+}
+
+// fatal 에러 로그를 남기고 종료
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
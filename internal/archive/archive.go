@@ -0,0 +1,310 @@
+// Package archive 업로드된 ZIP/TAR(.tar, .tar.gz) 파일의 항목 조회 및 단일 항목 추출
+//
+// 압축 폭탄을 막기 위해 항목 수, 전체 해제 크기, 압축률을 제한하며
+// 추출 시에도 선언된 크기 이상은 읽지 않는다.
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/korean"
+)
+
+// Format 지원하는 압축 형식
+type Format string
+
+const (
+	FormatZip   Format = "zip"
+	FormatTar   Format = "tar"
+	FormatTarGz Format = "tar.gz"
+)
+
+// ErrLimitExceeded 압축 폭탄 방지 제한 초과
+var ErrLimitExceeded = errors.New("압축 파일 제한 초과")
+
+// ErrEntryNotFound 요청한 항목이 없음
+var ErrEntryNotFound = errors.New("압축 파일 항목을 찾을 수 없습니다")
+
+// Limits 압축 폭탄 방지 제한
+type Limits struct {
+	MaxEntries   int   // 최대 항목 수
+	MaxTotalSize int64 // 해제 후 전체 크기 합계 최대 (바이트)
+	MaxRatio     int64 // 항목별 최대 압축률 (해제 크기 / 압축 크기, ZIP만 해당)
+}
+
+// Entry 압축 파일 항목
+type Entry struct {
+	Index          int // 압축 파일 안에서의 순서 (추출 시 식별자)
+	Name           string
+	Size           int64 // 해제 후 크기
+	CompressedSize int64 // 압축된 크기 (TAR는 Size와 같음)
+	ModifiedAt     time.Time
+	IsDir          bool
+}
+
+// Detect MIME 타입으로 압축 형식 판별 (지원하지 않으면 빈 문자열)
+func Detect(mimeType string) Format {
+	switch mimeType {
+	case "application/zip", "application/x-zip-compressed":
+		return FormatZip
+	case "application/x-tar":
+		return FormatTar
+	case "application/gzip", "application/x-gzip":
+		return FormatTarGz
+	}
+	return ""
+}
+
+// List 압축 파일의 항목 목록 조회 (제한을 넘으면 ErrLimitExceeded)
+func List(path string, format Format, limits Limits) ([]Entry, error) {
+	switch format {
+	case FormatZip:
+		return listZip(path, limits)
+	case FormatTar, FormatTarGz:
+		return listTar(path, format, limits)
+	}
+	return nil, fmt.Errorf("지원하지 않는 압축 형식: %s", format)
+}
+
+// Extract index번째 항목의 내용을 w에 기록 (maxSize보다 큰 항목은 거부)
+func Extract(path string, format Format, index int, maxSize int64, limits Limits, w io.Writer) error {
+	switch format {
+	case FormatZip:
+		return extractZip(path, index, maxSize, limits, w)
+	case FormatTar, FormatTarGz:
+		return extractTar(path, format, index, maxSize, limits, w)
+	}
+	return fmt.Errorf("지원하지 않는 압축 형식: %s", format)
+}
+
+// listZip 중앙 디렉토리만 읽어 항목 조회 (내용은 해제하지 않음)
+func listZip(path string, limits Limits) ([]Entry, error) {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("ZIP 파일 열기 실패: %v", err)
+	}
+	defer reader.Close()
+
+	if len(reader.File) > limits.MaxEntries {
+		return nil, fmt.Errorf("%w: 항목 수 %d개 (최대 %d개)", ErrLimitExceeded, len(reader.File), limits.MaxEntries)
+	}
+
+	entries := make([]Entry, 0, len(reader.File))
+	var total int64
+	for i, file := range reader.File {
+		if err := checkZipEntry(file, limits); err != nil {
+			return nil, err
+		}
+		total += int64(file.UncompressedSize64)
+		if total > limits.MaxTotalSize {
+			return nil, fmt.Errorf("%w: 해제 크기 합계가 %d바이트를 넘습니다", ErrLimitExceeded, limits.MaxTotalSize)
+		}
+
+		// DOS 날짜가 비어 있으면 수정 시각을 알 수 없음 (1979-11-30으로 해석되는 것 방지)
+		var modifiedAt time.Time
+		if file.ModifiedDate != 0 {
+			modifiedAt = file.Modified
+		}
+
+		entries = append(entries, Entry{
+			Index:          i,
+			Name:           entryName(file.Name),
+			Size:           int64(file.UncompressedSize64),
+			CompressedSize: int64(file.CompressedSize64),
+			ModifiedAt:     modifiedAt,
+			IsDir:          file.FileInfo().IsDir(),
+		})
+	}
+	return entries, nil
+}
+
+// checkZipEntry 선언된 크기와 압축률 확인
+func checkZipEntry(file *zip.File, limits Limits) error {
+	if file.UncompressedSize64 > uint64(limits.MaxTotalSize) {
+		return fmt.Errorf("%w: %s 항목이 너무 큽니다", ErrLimitExceeded, file.Name)
+	}
+	if limits.MaxRatio > 0 && file.UncompressedSize64 > 0 &&
+		file.UncompressedSize64 > uint64(limits.MaxRatio)*max(file.CompressedSize64, 1) {
+		return fmt.Errorf("%w: %s 항목의 압축률이 비정상적으로 높습니다", ErrLimitExceeded, file.Name)
+	}
+	return nil
+}
+
+// extractZip ZIP 항목 하나만 해제
+func extractZip(path string, index int, maxSize int64, limits Limits, w io.Writer) error {
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("ZIP 파일 열기 실패: %v", err)
+	}
+	defer reader.Close()
+
+	if index < 0 || index >= len(reader.File) {
+		return ErrEntryNotFound
+	}
+	file := reader.File[index]
+	if file.FileInfo().IsDir() {
+		return ErrEntryNotFound
+	}
+	if err := checkZipEntry(file, limits); err != nil {
+		return err
+	}
+	if int64(file.UncompressedSize64) > maxSize {
+		return fmt.Errorf("%w: 항목이 %d바이트를 넘습니다", ErrLimitExceeded, maxSize)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("ZIP 항목 열기 실패: %v", err)
+	}
+	defer src.Close()
+
+	// 선언된 크기와 실제 크기가 다른 경우에도 선언 크기 이상은 쓰지 않음
+	if _, err := io.Copy(w, io.LimitReader(src, int64(file.UncompressedSize64))); err != nil {
+		return fmt.Errorf("ZIP 항목 해제 실패: %v", err)
+	}
+	return nil
+}
+
+// openTar TAR 리더 생성 (tar.gz는 해제 크기 합계를 제한한 gzip 스트림 위에서 읽음)
+func openTar(path string, format Format, limits Limits) (*tar.Reader, func(), error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("압축 파일 열기 실패: %v", err)
+	}
+
+	var src io.Reader = file
+	closeFn := func() { file.Close() }
+	if format == FormatTarGz {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return nil, nil, fmt.Errorf("gzip 해제 실패: %v", err)
+		}
+		// TAR 헤더와 패딩을 고려해 전체 크기 제한에 여유를 둠
+		src = &limitedReader{r: gz, remaining: limits.MaxTotalSize + int64(limits.MaxEntries+2)*1024}
+		closeFn = func() {
+			gz.Close()
+			file.Close()
+		}
+	}
+	return tar.NewReader(src), closeFn, nil
+}
+
+// listTar 헤더를 순서대로 읽어 항목 조회
+func listTar(path string, format Format, limits Limits) ([]Entry, error) {
+	reader, closeFn, err := openTar(path, format, limits)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+
+	var entries []Entry
+	var total int64
+	for i := 0; ; i++ {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, tarError(err)
+		}
+		if i >= limits.MaxEntries {
+			return nil, fmt.Errorf("%w: 항목 수가 %d개를 넘습니다", ErrLimitExceeded, limits.MaxEntries)
+		}
+
+		total += header.Size
+		if header.Size < 0 || total > limits.MaxTotalSize {
+			return nil, fmt.Errorf("%w: 해제 크기 합계가 %d바이트를 넘습니다", ErrLimitExceeded, limits.MaxTotalSize)
+		}
+
+		entries = append(entries, Entry{
+			Index:          i,
+			Name:           entryName(header.Name),
+			Size:           header.Size,
+			CompressedSize: header.Size,
+			ModifiedAt:     header.ModTime,
+			IsDir:          header.Typeflag == tar.TypeDir || strings.HasSuffix(header.Name, "/"),
+		})
+	}
+	return entries, nil
+}
+
+// extractTar index번째 항목까지 읽어 내용 해제 (tar.gz는 앞 항목도 해제해야 하므로 스트림 전체에 제한 적용)
+func extractTar(path string, format Format, index int, maxSize int64, limits Limits, w io.Writer) error {
+	reader, closeFn, err := openTar(path, format, limits)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	for i := 0; i <= index && i < limits.MaxEntries; i++ {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return ErrEntryNotFound
+		}
+		if err != nil {
+			return tarError(err)
+		}
+		if i < index {
+			continue
+		}
+
+		if header.Typeflag != tar.TypeReg && header.Typeflag != tar.TypeRegA {
+			return ErrEntryNotFound
+		}
+		if header.Size > maxSize {
+			return fmt.Errorf("%w: 항목이 %d바이트를 넘습니다", ErrLimitExceeded, maxSize)
+		}
+		if _, err := io.Copy(w, io.LimitReader(reader, header.Size)); err != nil {
+			return fmt.Errorf("TAR 항목 해제 실패: %w", tarError(err))
+		}
+		return nil
+	}
+	return ErrEntryNotFound
+}
+
+// entryName 항목 이름을 UTF-8로 변환 (한국어 Windows에서 만든 ZIP은 CP949 파일명이 흔함)
+func entryName(name string) string {
+	if !utf8.ValidString(name) {
+		if decoded, err := korean.EUCKR.NewDecoder().String(name); err == nil {
+			name = decoded
+		}
+	}
+	// PostgreSQL TEXT에는 NUL 문자를 저장할 수 없음
+	return strings.ReplaceAll(strings.ToValidUTF8(name, "\uFFFD"), "\x00", "")
+}
+
+// tarError 해제 크기 제한 초과를 구분하여 에러 변환
+func tarError(err error) error {
+	if errors.Is(err, ErrLimitExceeded) {
+		return fmt.Errorf("%w: 해제 크기 합계 초과", ErrLimitExceeded)
+	}
+	return fmt.Errorf("TAR 파일 읽기 실패: %v", err)
+}
+
+// limitedReader 지정한 바이트를 넘게 읽으려 하면 ErrLimitExceeded 반환 (io.LimitReader는 조용히 EOF 처리)
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		return 0, ErrLimitExceeded
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
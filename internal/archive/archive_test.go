@@ -0,0 +1,300 @@
+package archive
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/text/encoding/korean"
+)
+
+// testLimits 테스트 기본 제한 (필요한 항목만 바꿔 사용)
+var testLimits = Limits{MaxEntries: 10, MaxTotalSize: 1 << 20, MaxRatio: 100}
+
+type fixtureEntry struct {
+	name    string
+	content []byte
+	nonUTF8 bool
+}
+
+// writeFixture 메모리에서 만든 압축 파일을 임시 파일로 기록하고 경로 반환
+func writeFixture(t *testing.T, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+	return path
+}
+
+func zipFixture(t *testing.T, method uint16, entries ...fixtureEntry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: entry.name, Method: method, NonUTF8: entry.nonUTF8})
+		if err != nil {
+			t.Fatalf("create %s: %v", entry.name, err)
+		}
+		if _, err := w.Write(entry.content); err != nil {
+			t.Fatalf("write %s: %v", entry.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("close zip: %v", err)
+	}
+	return writeFixture(t, buf.Bytes())
+}
+
+func tarGzFixture(t *testing.T, entries ...fixtureEntry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, entry := range entries {
+		header := &tar.Header{Name: entry.name, Mode: 0644, Size: int64(len(entry.content)), ModTime: time.Unix(0, 0), Typeflag: tar.TypeReg}
+		if strings.HasSuffix(entry.name, "/") {
+			header.Typeflag = tar.TypeDir
+			header.Size = 0
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatalf("write header %s: %v", entry.name, err)
+		}
+		if _, err := tw.Write(entry.content); err != nil {
+			t.Fatalf("write %s: %v", entry.name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("close gzip: %v", err)
+	}
+	return writeFixture(t, buf.Bytes())
+}
+
+// randomish 압축이 거의 되지 않는 데이터
+func randomish(n int) []byte {
+	data := make([]byte, n)
+	x := uint32(2463534242)
+	for i := range data {
+		x ^= x << 13
+		x ^= x >> 17
+		x ^= x << 5
+		data[i] = byte(x)
+	}
+	return data
+}
+
+func TestListAndExtractZip(t *testing.T) {
+	path := zipFixture(t, zip.Deflate,
+		fixtureEntry{name: "docs/"},
+		fixtureEntry{name: "docs/a.txt", content: []byte("hello")},
+		fixtureEntry{name: "b.bin", content: randomish(1000)},
+	)
+
+	entries, err := List(path, FormatZip, testLimits)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 3 || !entries[0].IsDir || entries[1].Name != "docs/a.txt" || entries[1].Size != 5 {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	var buf bytes.Buffer
+	if err := Extract(path, FormatZip, 1, 1024, testLimits, &buf); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if buf.String() != "hello" {
+		t.Errorf("Extract = %q, want %q", buf.String(), "hello")
+	}
+
+	if err := Extract(path, FormatZip, 0, 1024, testLimits, &bytes.Buffer{}); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("Extract(directory) error = %v, want ErrEntryNotFound", err)
+	}
+	if err := Extract(path, FormatZip, 3, 1024, testLimits, &bytes.Buffer{}); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("Extract(out of range) error = %v, want ErrEntryNotFound", err)
+	}
+	if err := Extract(path, FormatZip, 2, 999, testLimits, &bytes.Buffer{}); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Extract(larger than maxSize) error = %v, want ErrLimitExceeded", err)
+	}
+}
+
+func TestZipLimits(t *testing.T) {
+	many := make([]fixtureEntry, 11)
+	for i := range many {
+		many[i] = fixtureEntry{name: strings.Repeat("a", i+1), content: []byte("x")}
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		limits  Limits
+		wantErr bool
+	}{
+		{name: "entry count at limit", path: zipFixture(t, zip.Store, many[:10]...), limits: testLimits},
+		{name: "entry count over limit", path: zipFixture(t, zip.Store, many...), limits: testLimits, wantErr: true},
+		{
+			name:   "total size at limit",
+			path:   zipFixture(t, zip.Store, fixtureEntry{name: "a", content: randomish(600)}, fixtureEntry{name: "b", content: randomish(400)}),
+			limits: Limits{MaxEntries: 10, MaxTotalSize: 1000, MaxRatio: 100},
+		},
+		{
+			name:    "total size over limit",
+			path:    zipFixture(t, zip.Store, fixtureEntry{name: "a", content: randomish(600)}, fixtureEntry{name: "b", content: randomish(401)}),
+			limits:  Limits{MaxEntries: 10, MaxTotalSize: 1000, MaxRatio: 100},
+			wantErr: true,
+		},
+		{
+			name:    "single entry over total size",
+			path:    zipFixture(t, zip.Store, fixtureEntry{name: "a", content: randomish(1001)}),
+			limits:  Limits{MaxEntries: 10, MaxTotalSize: 1000, MaxRatio: 100},
+			wantErr: true,
+		},
+		{
+			name:    "compression ratio over limit",
+			path:    zipFixture(t, zip.Deflate, fixtureEntry{name: "zeros", content: make([]byte, 256<<10)}),
+			limits:  testLimits,
+			wantErr: true,
+		},
+		{
+			name:   "compression ratio check disabled",
+			path:   zipFixture(t, zip.Deflate, fixtureEntry{name: "zeros", content: make([]byte, 256<<10)}),
+			limits: Limits{MaxEntries: 10, MaxTotalSize: 1 << 20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := List(tt.path, FormatZip, tt.limits)
+			if tt.wantErr != errors.Is(err, ErrLimitExceeded) {
+				t.Errorf("List error = %v, want limit error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("List: %v", err)
+			}
+		})
+	}
+
+	// 목록을 건너뛰고 바로 추출해도 압축률 제한 적용
+	bomb := zipFixture(t, zip.Deflate, fixtureEntry{name: "zeros", content: make([]byte, 256<<10)})
+	if err := Extract(bomb, FormatZip, 0, 1<<20, testLimits, &bytes.Buffer{}); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Extract(bomb) error = %v, want ErrLimitExceeded", err)
+	}
+}
+
+func TestZipEntryNameCP949(t *testing.T) {
+	encoded, err := korean.EUCKR.NewEncoder().String("보고서/한글.txt")
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	path := zipFixture(t, zip.Store, fixtureEntry{name: encoded, content: []byte("x"), nonUTF8: true})
+
+	entries, err := List(path, FormatZip, testLimits)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "보고서/한글.txt" {
+		t.Errorf("entries = %+v, want decoded CP949 name", entries)
+	}
+}
+
+func TestListAndExtractTarGz(t *testing.T) {
+	path := tarGzFixture(t,
+		fixtureEntry{name: "docs/"},
+		fixtureEntry{name: "docs/a.txt", content: []byte("hello")},
+		fixtureEntry{name: "b.txt", content: []byte("world")},
+	)
+
+	entries, err := List(path, FormatTarGz, testLimits)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(entries) != 3 || !entries[0].IsDir || entries[2].Name != "b.txt" || entries[2].Size != 5 {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	var buf bytes.Buffer
+	if err := Extract(path, FormatTarGz, 2, 1024, testLimits, &buf); err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if buf.String() != "world" {
+		t.Errorf("Extract = %q, want %q", buf.String(), "world")
+	}
+
+	if err := Extract(path, FormatTarGz, 0, 1024, testLimits, &bytes.Buffer{}); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("Extract(directory) error = %v, want ErrEntryNotFound", err)
+	}
+	if err := Extract(path, FormatTarGz, 3, 1024, testLimits, &bytes.Buffer{}); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("Extract(out of range) error = %v, want ErrEntryNotFound", err)
+	}
+	if err := Extract(path, FormatTarGz, 1, 4, testLimits, &bytes.Buffer{}); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Extract(larger than maxSize) error = %v, want ErrLimitExceeded", err)
+	}
+}
+
+func TestTarGzLimits(t *testing.T) {
+	many := make([]fixtureEntry, 11)
+	for i := range many {
+		many[i] = fixtureEntry{name: strings.Repeat("a", i+1), content: []byte("x")}
+	}
+	if _, err := List(tarGzFixture(t, many[:10]...), FormatTarGz, testLimits); err != nil {
+		t.Errorf("List(entry count at limit): %v", err)
+	}
+	if _, err := List(tarGzFixture(t, many...), FormatTarGz, testLimits); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("List(entry count over limit) error = %v, want ErrLimitExceeded", err)
+	}
+
+	small := Limits{MaxEntries: 10, MaxTotalSize: 1000}
+	sized := tarGzFixture(t, fixtureEntry{name: "a", content: make([]byte, 600)}, fixtureEntry{name: "b", content: make([]byte, 401)})
+	if _, err := List(sized, FormatTarGz, small); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("List(total size over limit) error = %v, want ErrLimitExceeded", err)
+	}
+
+	// 뒤쪽 항목을 꺼내려면 앞 항목도 해제해야 하므로 gzip 스트림 전체 크기를 제한
+	bomb := tarGzFixture(t, fixtureEntry{name: "zeros", content: make([]byte, 64<<10)}, fixtureEntry{name: "b", content: []byte("x")})
+	if err := Extract(bomb, FormatTarGz, 1, 1024, small, &bytes.Buffer{}); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Extract(after oversized entry) error = %v, want ErrLimitExceeded", err)
+	}
+
+	// 항목 수 제한 밖의 항목은 찾지 않음
+	if err := Extract(tarGzFixture(t, many...), FormatTarGz, 10, 1024, testLimits, &bytes.Buffer{}); !errors.Is(err, ErrEntryNotFound) {
+		t.Errorf("Extract(beyond MaxEntries) error = %v, want ErrEntryNotFound", err)
+	}
+}
+
+func TestLimitedReader(t *testing.T) {
+	r := &limitedReader{r: strings.NewReader("abcdef"), remaining: 4}
+	buf := make([]byte, 10)
+	n, err := r.Read(buf)
+	if n != 4 || err != nil || string(buf[:n]) != "abcd" {
+		t.Fatalf("Read = %d, %v (%q), want 4 bytes", n, err, buf[:n])
+	}
+	if _, err := r.Read(buf); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("Read after limit error = %v, want ErrLimitExceeded", err)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := map[string]Format{
+		"application/zip":              FormatZip,
+		"application/x-zip-compressed": FormatZip,
+		"application/x-tar":            FormatTar,
+		"application/gzip":             FormatTarGz,
+		"application/x-gzip":           FormatTarGz,
+		"application/x-7z-compressed":  "",
+		"text/plain":                   "",
+	}
+	for mimeType, want := range tests {
+		if got := Detect(mimeType); got != want {
+			t.Errorf("Detect(%q) = %q, want %q", mimeType, got, want)
+		}
+	}
+}
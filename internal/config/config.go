@@ -1,79 +1,279 @@
 package config
 
 import (
-	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
+)
+
+// 실행 환경
+const (
+	EnvDevelopment = "development"
+	EnvProduction  = "production" // 안전하지 않은 기본값이 남아 있으면 시작하지 않음
 )
 
 type Config struct {
+	Env        string // development 또는 production
+	ConfigFile string // 읽어 들인 설정 파일 경로 (없으면 빈 문자열)
+
 	Database DatabaseConfig
 	Server   ServerConfig
 	File     FileConfig
+	Scanner  ScannerConfig
+	Jobs     JobConfig
+	Comment  CommentConfig
+	Archive  ArchiveConfig
+	Metrics  MetricsConfig
+	Log      LogConfig
+	Health   HealthConfig
 }
 
 type DatabaseConfig struct {
+	URL      string // DATABASE_URL (설정 시 아래 접속 정보 대신 그대로 사용)
 	Host     string
 	Port     string
 	User     string
 	Password string
 	Name     string
+
+	SSLMode        string // disable, require, verify-ca, verify-full
+	SSLRootCert    string // verify-ca/verify-full에서 사용할 CA 인증서 경로
+	ConnectTimeout int    // 연결 시도 제한 시간 (초, 0이면 제한 없음)
+	Options        string // 그 밖의 접속 옵션 (key=value 공백 구분, 예: application_name=board)
+
+	MaxOpenConns    int           // 최대 연결 수 (0이면 제한 없음)
+	MaxIdleConns    int           // 최대 유휴 연결 수
+	ConnMaxLifetime time.Duration // 연결 최대 수명 (0이면 제한 없음)
+	ConnMaxIdleTime time.Duration // 유휴 연결 최대 유지 시간 (0이면 제한 없음)
+
+	StartupTimeout time.Duration // 시작 시 데이터베이스가 준비될 때까지 재시도하는 최대 시간
+
+	QueryTimeout time.Duration // 게시글/파일 조회 제한 시간
+	WriteTimeout time.Duration // 게시글 저장/수정 제한 시간 (업로드 파일 복사 시간은 제외)
+	BulkTimeout  time.Duration // 일괄 처리, 통계, 내보내기 대상 조회 제한 시간
 }
 
 type ServerConfig struct {
-	Port          string
-	AdminPort     string
-	AdminPassword string
+	Port           string
+	AdminPort      string
+	AdminPassword  string
+	SessionSecret  string   // 관리자 세션 쿠키 서명 키
+	TrustedProxies []string // 프록시 헤더를 신뢰할 IP/CIDR 목록
+	ProxyProtocol  bool     // 리스너에서 PROXY protocol 헤더 수신 여부
+
+	ShutdownTimeout time.Duration // 종료 시 진행 중인 요청(업로드/다운로드)을 기다리는 최대 시간
 }
 
 type FileConfig struct {
-	UploadsDir  string
-	MaxFileSize int64
+	UploadsDir      string
+	MaxFileSize     int64
+	MaxFilesPerPost int // 게시글 하나에 첨부할 수 있는 최대 파일 수
+	MaxFolderFiles  int // 폴더 업로드 시 최대 파일 수
+	ThumbnailSize   int // 썸네일 긴 변 최대 픽셀
+}
+
+type ScannerConfig struct {
+	ClamdAddress string        // 비어 있으면 검사 비활성화 (예: tcp://clamav:3310)
+	Timeout      time.Duration // clamd 연결/응답 대기 시간
+}
+
+type JobConfig struct {
+	Workers           int           // 워커 고루틴 수
+	PollInterval      time.Duration // 대기 작업 조회 주기
+	VisibilityTimeout time.Duration // 실행 중 작업을 다른 워커가 다시 가져가기까지의 시간
+	MaxAttempts       int           // 작업별 최대 시도 횟수
+	RetryBaseDelay    time.Duration // 재시도 백오프 기본 지연
+	RetryMaxDelay     time.Duration // 재시도 백오프 최대 지연
+	DoneRetention     time.Duration // 완료된 작업 기록 보관 기간 (0이면 삭제하지 않음)
+}
+
+type CommentConfig struct {
+	MaxLength  int           // 댓글 최대 길이 (글자 수)
+	RateLimit  int           // RateWindow 동안 IP별로 작성할 수 있는 댓글 수
+	RateWindow time.Duration // 작성 제한 기간
 }
 
-func Load() *Config {
-	return &Config{
+type ArchiveConfig struct {
+	MaxEntries   int   // 색인할 수 있는 최대 항목 수
+	MaxTotalSize int64 // 해제 후 전체 크기 합계 최대 (바이트)
+	MaxEntrySize int64 // 단일 항목 추출 최대 크기 (바이트)
+	MaxRatio     int64 // 항목별 최대 압축률 (압축 폭탄 방지)
+}
+
+type MetricsConfig struct {
+	Port  string // 비어 있지 않으면 이 포트에서 별도로 제공, 비어 있으면 관리자 서버의 Path에서 제공
+	Path  string // 지표 경로
+	Token string // 설정 시 Authorization: Bearer 토큰 필요 (관리자 서버에서는 없으면 관리자 로그인 필요)
+}
+
+type LogConfig struct {
+	Format string // json 또는 text
+	Level  string // debug, info, warn, error
+}
+
+type HealthConfig struct {
+	MinFreeSpace int64         // 업로드 디렉토리 최소 여유 공간 (바이트, 미만이면 준비 안 됨)
+	CheckTimeout time.Duration // 준비 상태 확인 전체 제한 시간
+}
+
+// Load 설정 로드 (우선순위: 명령줄 -set > 환경 변수 > 설정 파일 > 기본값)
+//
+// 설정 파일은 -config 또는 CONFIG_FILE로 지정하며 확장자로 형식(YAML/TOML)을 정한다.
+// 잘못된 값이나 알 수 없는 키가 있으면 기본값으로 대체하지 않고 오류를 반환한다.
+func Load(args []string) (*Config, error) {
+	s, err := newSource(args)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		Env:        s.string("env", "APP_ENV", EnvDevelopment),
+		ConfigFile: s.path,
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", ""),
-			Password: getEnv("DB_PASSWORD", ""),
-			Name:     getEnv("DB_NAME", ""),
+			URL:      s.string("database.url", "DATABASE_URL", ""),
+			Host:     s.string("database.host", "DB_HOST", "localhost"),
+			Port:     s.string("database.port", "DB_PORT", "5432"),
+			User:     s.string("database.user", "DB_USER", ""),
+			Password: s.string("database.password", "DB_PASSWORD", ""),
+			Name:     s.string("database.name", "DB_NAME", ""),
+
+			SSLMode:        s.string("database.sslmode", "DB_SSLMODE", "disable"),
+			SSLRootCert:    s.string("database.sslrootcert", "DB_SSLROOTCERT", ""),
+			ConnectTimeout: s.int("database.connect_timeout_sec", "DB_CONNECT_TIMEOUT_SEC", 5),
+			Options:        s.string("database.options", "DB_OPTIONS", ""),
+
+			MaxOpenConns:    s.int("database.max_open_conns", "DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    s.int("database.max_idle_conns", "DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetime: s.seconds("database.conn_max_lifetime_sec", "DB_CONN_MAX_LIFETIME_SEC", 1800),
+			ConnMaxIdleTime: s.seconds("database.conn_max_idle_time_sec", "DB_CONN_MAX_IDLE_TIME_SEC", 300),
+
+			StartupTimeout: s.seconds("database.startup_timeout_sec", "DB_STARTUP_TIMEOUT_SEC", 60),
+
+			QueryTimeout: s.seconds("database.query_timeout_sec", "DB_QUERY_TIMEOUT_SEC", 10),
+			WriteTimeout: s.seconds("database.write_timeout_sec", "DB_WRITE_TIMEOUT_SEC", 30),
+			BulkTimeout:  s.seconds("database.bulk_timeout_sec", "DB_BULK_TIMEOUT_SEC", 120),
 		},
 		Server: ServerConfig{
-			Port:          getEnv("SERVER_PORT", "80"),
-			AdminPort:     getEnv("ADMIN_PORT", "8081"),
-			AdminPassword: getEnv("ADMIN_PASSWORD", "admin123"),
+			Port:           s.string("server.port", "SERVER_PORT", "80"),
+			AdminPort:      s.string("server.admin_port", "ADMIN_PORT", "8081"),
+			AdminPassword:  s.string("server.admin_password", "ADMIN_PASSWORD", "admin123"),
+			TrustedProxies: s.list("server.trusted_proxies", "TRUSTED_PROXIES"),
+			ProxyProtocol:  s.bool("server.proxy_protocol", "PROXY_PROTOCOL", false),
+			SessionSecret:  s.string("server.session_secret", "SESSION_SECRET", ""),
+
+			ShutdownTimeout: s.seconds("server.shutdown_timeout_sec", "SHUTDOWN_TIMEOUT_SEC", 120),
 		},
 		File: FileConfig{
-			UploadsDir:        "files",
-			MaxFileSize:       getEnvInt64("MAX_FILE_SIZE_MB", 500) * 1024 * 1024,
+			UploadsDir:      s.string("file.uploads_dir", "UPLOADS_DIR", "files"),
+			MaxFileSize:     s.megabytes("file.max_file_size_mb", "MAX_FILE_SIZE_MB", 500),
+			MaxFilesPerPost: s.int("file.max_files_per_post", "MAX_FILES_PER_POST", 20),
+			MaxFolderFiles:  s.int("file.max_folder_files", "MAX_FOLDER_FILES", 500),
+			ThumbnailSize:   s.int("file.thumbnail_size", "THUMBNAIL_SIZE", 320),
+		},
+		Scanner: ScannerConfig{
+			ClamdAddress: s.string("scanner.clamd_address", "CLAMD_ADDRESS", ""),
+			Timeout:      s.seconds("scanner.timeout_sec", "CLAMD_TIMEOUT_SEC", 60),
+		},
+		Jobs: JobConfig{
+			Workers:           s.int("jobs.workers", "JOB_WORKERS", 4),
+			PollInterval:      s.seconds("jobs.poll_interval_sec", "JOB_POLL_INTERVAL_SEC", 2),
+			VisibilityTimeout: s.seconds("jobs.visibility_timeout_sec", "JOB_VISIBILITY_TIMEOUT_SEC", 300),
+			MaxAttempts:       s.int("jobs.max_attempts", "JOB_MAX_ATTEMPTS", 5),
+			RetryBaseDelay:    10 * time.Second,
+			RetryMaxDelay:     time.Hour,
+			DoneRetention:     s.seconds("jobs.done_retention_sec", "JOB_DONE_RETENTION_SEC", 7*24*60*60),
+		},
+		Comment: CommentConfig{
+			MaxLength:  s.int("comment.max_length", "COMMENT_MAX_LENGTH", 2000),
+			RateLimit:  s.int("comment.rate_limit", "COMMENT_RATE_LIMIT", 5),
+			RateWindow: s.seconds("comment.rate_window_sec", "COMMENT_RATE_WINDOW_SEC", 60),
+		},
+		Archive: ArchiveConfig{
+			MaxEntries:   s.int("archive.max_entries", "ARCHIVE_MAX_ENTRIES", 10000),
+			MaxTotalSize: s.megabytes("archive.max_total_mb", "ARCHIVE_MAX_TOTAL_MB", 10240),
+			MaxEntrySize: s.megabytes("archive.max_entry_mb", "ARCHIVE_MAX_ENTRY_MB", 1024),
+			MaxRatio:     s.int64("archive.max_ratio", "ARCHIVE_MAX_RATIO", 1000),
+		},
+		Metrics: MetricsConfig{
+			Port:  s.string("metrics.port", "METRICS_PORT", ""),
+			Path:  s.string("metrics.path", "METRICS_PATH", "/metrics"),
+			Token: s.string("metrics.token", "METRICS_TOKEN", ""),
+		},
+		Log: LogConfig{
+			Format: s.string("log.format", "LOG_FORMAT", "text"),
+			Level:  s.string("log.level", "LOG_LEVEL", "info"),
+		},
+		Health: HealthConfig{
+			MinFreeSpace: s.megabytes("health.min_free_mb", "HEALTH_MIN_FREE_MB", 1024),
+			CheckTimeout: s.seconds("health.timeout_sec", "HEALTH_TIMEOUT_SEC", 3),
 		},
 	}
-}
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+	if err := s.err(); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
 	}
-	return defaultValue
+	return cfg, nil
 }
 
-func getEnvInt64(key string, defaultValue int64) int64 {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.ParseInt(value, 10, 64); err == nil {
-			return intValue
+// GetDatabaseURL 데이터베이스 접속 문자열 (DATABASE_URL이 있으면 그대로, 없으면 DB_* 설정으로 구성)
+func (c *Config) GetDatabaseURL() string {
+	if c.Database.URL != "" {
+		return c.Database.URL
+	}
+
+	params := [][2]string{
+		{"host", c.Database.Host},
+		{"port", c.Database.Port},
+		{"user", c.Database.User},
+		{"password", c.Database.Password},
+		{"dbname", c.Database.Name},
+		{"sslmode", c.Database.SSLMode},
+	}
+	if c.Database.SSLRootCert != "" {
+		params = append(params, [2]string{"sslrootcert", c.Database.SSLRootCert})
+	}
+	if c.Database.ConnectTimeout > 0 {
+		params = append(params, [2]string{"connect_timeout", strconv.Itoa(c.Database.ConnectTimeout)})
+	}
+
+	parts := make([]string, 0, len(params)+1)
+	for _, p := range params {
+		// 비어 있는 값은 생략해 드라이버 기본값(PG* 환경 변수 등)을 사용
+		if p[1] != "" {
+			parts = append(parts, p[0]+"="+quoteDSNValue(p[1]))
 		}
 	}
-	return defaultValue
+	if c.Database.Options != "" {
+		parts = append(parts, c.Database.Options)
+	}
+	return strings.Join(parts, " ")
 }
 
-func (c *Config) GetDatabaseURL() string {
-	return "host=" + c.Database.Host +
-		" port=" + c.Database.Port +
-		" user=" + c.Database.User +
-		" password=" + c.Database.Password +
-		" dbname=" + c.Database.Name +
-		" sslmode=disable"
+// quoteDSNValue key=value 접속 문자열 값 인용 (공백, 따옴표, 역슬래시가 있으면 작은따옴표로 감쌈)
+func quoteDSNValue(value string) string {
+	if !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// GetQuarantineDir 악성코드가 탐지된 파일을 격리하는 디렉토리
+func (c *Config) GetQuarantineDir() string {
+	return filepath.Join(c.File.UploadsDir, "quarantine")
+}
+
+// GetThumbnailDir 썸네일 저장 디렉토리
+func (c *Config) GetThumbnailDir() string {
+	return filepath.Join(c.File.UploadsDir, "thumbs")
+}
+
+// IsProduction 운영 모드 여부
+func (c *Config) IsProduction() bool {
+	return c.Env == EnvProduction
 }
 
 func (c *Config) GetMaxFileSizeMB() int64 {
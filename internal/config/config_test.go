@@ -0,0 +1,243 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// loadForTest 환경 변수 영향 없이 설정 로드
+func loadForTest(t *testing.T, args ...string) (*Config, error) {
+	t.Helper()
+	for _, env := range []string{"CONFIG_FILE", "APP_ENV", "SERVER_PORT", "ADMIN_PORT", "ADMIN_PASSWORD", "SESSION_SECRET", "DATABASE_URL", "DB_PASSWORD", "METRICS_PORT", "METRICS_TOKEN", "JOB_WORKERS"} {
+		t.Setenv(env, "")
+	}
+	return Load(args)
+}
+
+// writeConfig 임시 디렉토리에 설정 파일을 만들고 경로 반환
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	return path
+}
+
+// validConfig 기본값으로 로드한 개발 모드 설정
+func validConfig(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := loadForTest(t)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return cfg
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg := validConfig(t)
+	if cfg.Env != EnvDevelopment || cfg.Server.Port != "80" || cfg.Server.AdminPort != "8081" {
+		t.Errorf("defaults = env %q, port %q, admin port %q", cfg.Env, cfg.Server.Port, cfg.Server.AdminPort)
+	}
+	if cfg.File.MaxFileSize != 500<<20 {
+		t.Errorf("MaxFileSize = %d, want %d", cfg.File.MaxFileSize, 500<<20)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeConfig(t, "board.yaml", "server:\n  port: 9000\n  trusted_proxies: [10.0.0.1, 10.1.0.0/16]\n")
+
+	cfg, err := loadForTest(t, "-config", path)
+	if err != nil {
+		t.Fatalf("Load(file): %v", err)
+	}
+	if cfg.Server.Port != "9000" || len(cfg.Server.TrustedProxies) != 2 {
+		t.Errorf("file values = port %q, proxies %v", cfg.Server.Port, cfg.Server.TrustedProxies)
+	}
+
+	t.Setenv("SERVER_PORT", "9001")
+	cfg, err = Load([]string{"-config", path})
+	if err != nil || cfg.Server.Port != "9001" {
+		t.Errorf("env over file = %v, %v, want port 9001", cfg, err)
+	}
+
+	cfg, err = Load([]string{"-config", path, "-set", "server.port=9002"})
+	if err != nil || cfg.Server.Port != "9002" {
+		t.Errorf("-set over env = %v, %v, want port 9002", cfg, err)
+	}
+}
+
+func TestLoadTOML(t *testing.T) {
+	path := writeConfig(t, "board.toml", "[jobs]\nworkers = 8\n")
+	cfg, err := loadForTest(t, "-config", path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Jobs.Workers != 8 {
+		t.Errorf("Workers = %d, want 8", cfg.Jobs.Workers)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	yamlTypo := writeConfig(t, "typo.yaml", "server:\n  prot: 9000\n")
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{name: "unknown file key", args: []string{"-config", yamlTypo}, want: []string{"알 수 없는 설정 키: server.prot"}},
+		{name: "unknown -set key", args: []string{"-set", "server.prot=1"}, want: []string{"알 수 없는 설정 키: server.prot"}},
+		{name: "invalid integer", args: []string{"-set", "jobs.workers=many"}, want: []string{"-set jobs.workers: 정수가 아닙니다"}},
+		{name: "invalid bool", args: []string{"-set", "server.proxy_protocol=maybe"}, want: []string{"true 또는 false가 아닙니다"}},
+		{name: "negative seconds", args: []string{"-set", "jobs.poll_interval_sec=-1"}, want: []string{"jobs.poll_interval_sec: 0 이상의 초 단위 값"}},
+		{name: "collects every error", args: []string{"-set", "jobs.workers=x", "-set", "bogus=1"}, want: []string{"jobs.workers", "bogus"}},
+		{name: "bad -set format", args: []string{"-set", "novalue"}, want: []string{"key=value"}},
+		{name: "extra argument", args: []string{"extra"}, want: []string{"알 수 없는 인수: extra"}},
+		{name: "unsupported extension", args: []string{"-config", writeConfig(t, "board.ini", "")}, want: []string{"지원하지 않는 설정 파일 형식"}},
+		{name: "validation", args: []string{"-set", "server.admin_port=80"}, want: []string{"ADMIN_PORT: SERVER_PORT와 달라야"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadForTest(t, tt.args...)
+			if err == nil {
+				t.Fatal("Load succeeded, want error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error = %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(c *Config)
+		want   string
+	}{
+		{"env", func(c *Config) { c.Env = "staging" }, "APP_ENV"},
+		{"port", func(c *Config) { c.Server.Port = "70000" }, "SERVER_PORT"},
+		{"metrics port clash", func(c *Config) { c.Metrics.Port = c.Server.AdminPort }, "METRICS_PORT"},
+		{"trusted proxy", func(c *Config) { c.Server.TrustedProxies = []string{"10.0.0.0/33"} }, "TRUSTED_PROXIES"},
+		{"proxy protocol without proxies", func(c *Config) { c.Server.ProxyProtocol = true }, "PROXY_PROTOCOL"},
+		{"sslmode", func(c *Config) { c.Database.SSLMode = "on" }, "DB_SSLMODE"},
+		{"uploads dir", func(c *Config) { c.File.UploadsDir = " " }, "UPLOADS_DIR"},
+		{"max file size", func(c *Config) { c.File.MaxFileSize = 0 }, "MAX_FILE_SIZE_MB"},
+		{"job workers", func(c *Config) { c.Jobs.Workers = 0 }, "JOB_WORKERS"},
+		{"metrics path", func(c *Config) { c.Metrics.Path = "metrics" }, "METRICS_PATH"},
+		{"log format", func(c *Config) { c.Log.Format = "xml" }, "LOG_FORMAT"},
+		{"log level", func(c *Config) { c.Log.Level = "verbose" }, "LOG_LEVEL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validConfig(t)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() = %v, want error mentioning %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateProduction(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Env = EnvProduction
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Validate() with development defaults in production succeeded")
+	}
+	for _, want := range []string{"ADMIN_PASSWORD", "SESSION_SECRET", "DB_PASSWORD"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error = %q, want it to mention %s", err, want)
+		}
+	}
+
+	cfg.Server.AdminPassword = "correct-horse-battery"
+	cfg.Server.SessionSecret = strings.Repeat("s", minSessionSecretLength)
+	cfg.Database.Password = "db-secret"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with production values: %v", err)
+	}
+
+	for _, weak := range []string{"ChangeMe", "short-pass"} {
+		cfg.Server.AdminPassword = weak
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "ADMIN_PASSWORD") {
+			t.Errorf("Validate() with admin password %q = %v, want ADMIN_PASSWORD error", weak, err)
+		}
+	}
+	cfg.Server.AdminPassword = "correct-horse-battery"
+
+	// DATABASE_URL을 쓰면 DB_PASSWORD는 요구하지 않음
+	cfg.Database.Password = ""
+	cfg.Database.URL = "postgres://board@db/board"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with DATABASE_URL: %v", err)
+	}
+
+	cfg.Metrics.Port = "9100"
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "METRICS_TOKEN") {
+		t.Errorf("Validate() with metrics port and no token = %v, want METRICS_TOKEN error", err)
+	}
+	cfg.Metrics.Token = "token"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Validate() with metrics token: %v", err)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"postgres://board:secret@db:5432/board?sslmode=require", "postgres://board:xxxxx@db:5432/board?sslmode=require"},
+		{"postgres://db/board?password=secret&sslmode=disable", "postgres://db/board?password=%5BREDACTED%5D&sslmode=disable"},
+		{"postgres://board@db/board", "postgres://board@db/board"},
+		{"host=db password=secret dbname=board", "host=db password=[REDACTED] dbname=board"},
+		{"host=db password = secret dbname=board", "host=db password = [REDACTED] dbname=board"},
+		{"host=db password='a \\' b' dbname=board", "host=db password=[REDACTED] dbname=board"},
+	}
+	for _, tt := range tests {
+		if got := redactURL(tt.input); got != tt.want {
+			t.Errorf("redactURL(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestDSNPasswordPattern(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"options='-c search_path=board'", "options='-c search_path=board'"},
+		{"password=secret", "password=[REDACTED]"},
+		{"password='two words' sslmode=require", "password=[REDACTED] sslmode=require"},
+		{`password='a\'b' port=5432`, "password=[REDACTED] port=5432"},
+	}
+	for _, tt := range tests {
+		if got := dsnPasswordPattern.ReplaceAllString(tt.input, "${1}"+redacted); got != tt.want {
+			t.Errorf("replace(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestGetDatabaseURL(t *testing.T) {
+	cfg := validConfig(t)
+	cfg.Database.User = "board"
+	cfg.Database.Password = `it's a \secret`
+	cfg.Database.Name = "board"
+	cfg.Database.Options = "application_name=board"
+
+	want := `host=localhost port=5432 user=board password='it\'s a \\secret' dbname=board sslmode=disable connect_timeout=5 application_name=board`
+	if got := cfg.GetDatabaseURL(); got != want {
+		t.Errorf("GetDatabaseURL() =\n  %s\nwant\n  %s", got, want)
+	}
+	if got := redactURL(cfg.GetDatabaseURL()); strings.Contains(got, "secret") {
+		t.Errorf("redactURL(GetDatabaseURL()) = %q, leaks the password", got)
+	}
+}
@@ -0,0 +1,234 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// source 설정 값 출처 (명령줄 -set, 환경 변수, 설정 파일)
+//
+// 각 설정은 파일 키(section.name)와 환경 변수 이름으로 조회하며,
+// 값 해석 오류는 모아 두었다가 err()에서 한꺼번에 반환한다.
+type source struct {
+	path      string            // 설정 파일 경로
+	file      map[string]string // 설정 파일 값 (section.name → 값)
+	overrides map[string]string // 명령줄 -set 값
+	used      map[string]bool   // 조회한 키 (알 수 없는 키 확인용)
+	errs      []error
+}
+
+// newSource 명령줄 인수를 해석하고 설정 파일을 읽음
+func newSource(args []string) (*source, error) {
+	s := &source{
+		file:      map[string]string{},
+		overrides: map[string]string{},
+		used:      map[string]bool{},
+	}
+
+	fs := flag.NewFlagSet("board", flag.ContinueOnError)
+	fs.StringVar(&s.path, "config", os.Getenv("CONFIG_FILE"), "설정 파일 경로 (.yaml, .yml, .toml)")
+	env := fs.String("env", "", "실행 환경 (development, production), APP_ENV보다 우선")
+	fs.Func("set", "설정 값 지정 (key=value, 여러 번 사용 가능), 환경 변수와 설정 파일보다 우선", func(value string) error {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return fmt.Errorf("key=value 형식이 아닙니다: %s", value)
+		}
+		s.overrides[strings.TrimSpace(key)] = val
+		return nil
+	})
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("알 수 없는 인수: %s", strings.Join(fs.Args(), " "))
+	}
+	if *env != "" {
+		s.overrides["env"] = *env
+	}
+
+	if s.path != "" {
+		values, err := readConfigFile(s.path)
+		if err != nil {
+			return nil, err
+		}
+		s.file = values
+	}
+	return s, nil
+}
+
+// readConfigFile 설정 파일을 읽어 section.name 형식의 평면 맵으로 변환
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("설정 파일 읽기 실패: %v", err)
+	}
+
+	tree := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &tree)
+	case ".toml":
+		err = toml.Unmarshal(data, &tree)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 설정 파일 형식: %s (.yaml, .yml, .toml)", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("설정 파일 해석 실패 (%s): %v", path, err)
+	}
+
+	values := map[string]string{}
+	if err := flatten("", tree, values); err != nil {
+		return nil, fmt.Errorf("설정 파일 해석 실패 (%s): %v", path, err)
+	}
+	return values, nil
+}
+
+// flatten 중첩된 표를 점으로 연결한 키로 펼침 (목록은 쉼표로 연결)
+func flatten(prefix string, tree map[string]interface{}, out map[string]string) error {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if err := flatten(key, v, out); err != nil {
+				return err
+			}
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				if _, nested := item.(map[string]interface{}); nested {
+					return fmt.Errorf("%s: 목록에는 단순 값만 사용할 수 있습니다", key)
+				}
+				items = append(items, fmt.Sprint(item))
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+	return nil
+}
+
+// lookup 우선순위에 따라 값을 찾음 (빈 값은 설정하지 않은 것으로 봄)
+func (s *source) lookup(key, env string) (value, origin string, ok bool) {
+	s.used[key] = true
+	if v, ok := s.overrides[key]; ok && v != "" {
+		return v, "-set " + key, true
+	}
+	if v := os.Getenv(env); v != "" {
+		return v, env, true
+	}
+	if v, ok := s.file[key]; ok && v != "" {
+		return v, s.path + ": " + key, true
+	}
+	return "", "", false
+}
+
+func (s *source) string(key, env, defaultValue string) string {
+	if value, _, ok := s.lookup(key, env); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func (s *source) int64(key, env string, defaultValue int64) int64 {
+	value, origin, ok := s.lookup(key, env)
+	if !ok {
+		return defaultValue
+	}
+	intValue, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("%s: 정수가 아닙니다 (%q)", origin, value))
+		return defaultValue
+	}
+	return intValue
+}
+
+func (s *source) int(key, env string, defaultValue int) int {
+	value := s.int64(key, env, int64(defaultValue))
+	if value > math.MaxInt32 || value < math.MinInt32 {
+		s.errs = append(s.errs, fmt.Errorf("%s: 값이 너무 큽니다 (%d)", key, value))
+		return defaultValue
+	}
+	return int(value)
+}
+
+func (s *source) bool(key, env string, defaultValue bool) bool {
+	value, origin, ok := s.lookup(key, env)
+	if !ok {
+		return defaultValue
+	}
+	boolValue, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		s.errs = append(s.errs, fmt.Errorf("%s: true 또는 false가 아닙니다 (%q)", origin, value))
+		return defaultValue
+	}
+	return boolValue
+}
+
+// list 쉼표로 구분된 값을 목록으로 변환 (빈 항목 제외)
+func (s *source) list(key, env string) []string {
+	value, _, _ := s.lookup(key, env)
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// seconds 초 단위 정수를 time.Duration으로 변환
+func (s *source) seconds(key, env string, defaultValue int64) time.Duration {
+	value := s.int64(key, env, defaultValue)
+	if value < 0 || value > math.MaxInt64/int64(time.Second) {
+		s.errs = append(s.errs, fmt.Errorf("%s: 0 이상의 초 단위 값이어야 합니다 (%d)", key, value))
+		return time.Duration(defaultValue) * time.Second
+	}
+	return time.Duration(value) * time.Second
+}
+
+// megabytes MB 단위 정수를 바이트로 변환
+func (s *source) megabytes(key, env string, defaultValue int64) int64 {
+	value := s.int64(key, env, defaultValue)
+	if value < 0 || value > math.MaxInt64>>20 {
+		s.errs = append(s.errs, fmt.Errorf("%s: 0 이상의 MB 단위 값이어야 합니다 (%d)", key, value))
+		return defaultValue << 20
+	}
+	return value << 20
+}
+
+// err 값 해석 오류와 알 수 없는 키(오타 등)를 합쳐 반환
+func (s *source) err() error {
+	errs := s.errs
+	for _, keys := range []map[string]string{s.file, s.overrides} {
+		var unknown []string
+		for key := range keys {
+			if !s.used[key] {
+				unknown = append(unknown, key)
+			}
+		}
+		sort.Strings(unknown)
+		for _, key := range unknown {
+			errs = append(errs, fmt.Errorf("알 수 없는 설정 키: %s", key))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("설정 오류:\n%w", errors.Join(errs...))
+}
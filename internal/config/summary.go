@@ -0,0 +1,92 @@
+package config
+
+import (
+	"log/slog"
+	"net/url"
+	"regexp"
+)
+
+// 비밀 값을 가리는 표시
+const redacted = "[REDACTED]"
+
+// key=value 접속 문자열의 password 항목 (작은따옴표로 감싼 값 포함)
+var dsnPasswordPattern = regexp.MustCompile(`(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// LogValue 시작 시 기록할 적용 설정 요약 (비밀번호, 토큰, 세션 키 등은 가림)
+func (c *Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("env", c.Env),
+		slog.String("config_file", c.ConfigFile),
+		slog.Group("database",
+			slog.String("url", redactURL(c.Database.URL)),
+			slog.String("host", c.Database.Host),
+			slog.String("port", c.Database.Port),
+			slog.String("user", c.Database.User),
+			slog.String("password", redact(c.Database.Password)),
+			slog.String("name", c.Database.Name),
+			slog.String("sslmode", c.Database.SSLMode),
+			slog.String("options", dsnPasswordPattern.ReplaceAllString(c.Database.Options, "${1}"+redacted)),
+			slog.Int("max_open_conns", c.Database.MaxOpenConns),
+			slog.Int("max_idle_conns", c.Database.MaxIdleConns),
+			slog.Duration("query_timeout", c.Database.QueryTimeout),
+			slog.Duration("write_timeout", c.Database.WriteTimeout),
+			slog.Duration("bulk_timeout", c.Database.BulkTimeout),
+		),
+		slog.Group("server",
+			slog.String("port", c.Server.Port),
+			slog.String("admin_port", c.Server.AdminPort),
+			slog.String("admin_password", redact(c.Server.AdminPassword)),
+			slog.String("session_secret", redact(c.Server.SessionSecret)),
+			slog.Any("trusted_proxies", c.Server.TrustedProxies),
+			slog.Bool("proxy_protocol", c.Server.ProxyProtocol),
+			slog.Duration("shutdown_timeout", c.Server.ShutdownTimeout),
+		),
+		slog.Group("file",
+			slog.String("uploads_dir", c.File.UploadsDir),
+			slog.Int64("max_file_size_mb", c.GetMaxFileSizeMB()),
+			slog.Int("max_files_per_post", c.File.MaxFilesPerPost),
+			slog.Int("max_folder_files", c.File.MaxFolderFiles),
+		),
+		slog.Group("scanner",
+			slog.String("clamd_address", c.Scanner.ClamdAddress),
+		),
+		slog.Group("jobs",
+			slog.Int("workers", c.Jobs.Workers),
+			slog.Int("max_attempts", c.Jobs.MaxAttempts),
+		),
+		slog.Group("metrics",
+			slog.String("port", c.Metrics.Port),
+			slog.String("path", c.Metrics.Path),
+			slog.String("token", redact(c.Metrics.Token)),
+		),
+		slog.Group("log",
+			slog.String("format", c.Log.Format),
+			slog.String("level", c.Log.Level),
+		),
+	)
+}
+
+// redact 설정 여부만 남기고 값을 가림
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return redacted
+}
+
+// redactURL 접속 URL의 비밀번호를 가림 (key=value 형식이면 password 항목을 가림)
+func redactURL(value string) string {
+	if value == "" {
+		return ""
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Scheme == "" {
+		return dsnPasswordPattern.ReplaceAllString(value, "${1}"+redacted)
+	}
+	query := u.Query()
+	if query.Has("password") {
+		query.Set("password", redacted)
+		u.RawQuery = query.Encode()
+	}
+	return u.Redacted()
+}
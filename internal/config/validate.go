@@ -0,0 +1,144 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// 운영 모드에서 허용하지 않는 관리자 비밀번호
+var weakAdminPasswords = map[string]bool{
+	"admin":    true,
+	"admin123": true,
+	"password": true,
+	"changeme": true,
+}
+
+const (
+	minProductionPasswordLength = 12
+	minSessionSecretLength      = 32
+)
+
+// Validate 설정 값 검사 (운영 모드에서는 안전하지 않은 기본값도 거부)
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Env == EnvDevelopment || c.Env == EnvProduction, "APP_ENV: development 또는 production이어야 합니다 (%q)", c.Env)
+
+	// 서버
+	checkPort := func(name, port string) {
+		check(validPort(port), "%s: 1-65535 사이의 포트 번호여야 합니다 (%q)", name, port)
+	}
+	checkPort("SERVER_PORT", c.Server.Port)
+	checkPort("ADMIN_PORT", c.Server.AdminPort)
+	check(c.Server.Port != c.Server.AdminPort, "ADMIN_PORT: SERVER_PORT와 달라야 합니다 (%s)", c.Server.AdminPort)
+	if c.Metrics.Port != "" {
+		checkPort("METRICS_PORT", c.Metrics.Port)
+		check(c.Metrics.Port != c.Server.Port && c.Metrics.Port != c.Server.AdminPort,
+			"METRICS_PORT: SERVER_PORT, ADMIN_PORT와 달라야 합니다 (%s)", c.Metrics.Port)
+	}
+	for _, entry := range c.Server.TrustedProxies {
+		check(validIPOrCIDR(entry), "TRUSTED_PROXIES: IP 또는 CIDR이 아닙니다 (%q)", entry)
+	}
+	// 신뢰할 대역 없이 PROXY 헤더를 받으면 누구나 출발지 IP를 속일 수 있음
+	check(!c.Server.ProxyProtocol || len(c.Server.TrustedProxies) > 0, "PROXY_PROTOCOL: TRUSTED_PROXIES에 프록시 주소를 지정해야 합니다")
+	check(c.Server.ShutdownTimeout > 0, "SHUTDOWN_TIMEOUT_SEC: 0보다 커야 합니다")
+
+	// 데이터베이스
+	switch c.Database.SSLMode {
+	case "disable", "allow", "prefer", "require", "verify-ca", "verify-full":
+	default:
+		errs = append(errs, fmt.Errorf("DB_SSLMODE: 지원하지 않는 값입니다 (%q)", c.Database.SSLMode))
+	}
+	if c.Database.URL == "" && c.Database.Port != "" {
+		checkPort("DB_PORT", c.Database.Port)
+	}
+	check(c.Database.ConnectTimeout >= 0, "DB_CONNECT_TIMEOUT_SEC: 0 이상이어야 합니다")
+	check(c.Database.MaxOpenConns >= 0, "DB_MAX_OPEN_CONNS: 0 이상이어야 합니다")
+	check(c.Database.MaxIdleConns >= 0, "DB_MAX_IDLE_CONNS: 0 이상이어야 합니다")
+	check(c.Database.StartupTimeout > 0, "DB_STARTUP_TIMEOUT_SEC: 0보다 커야 합니다")
+
+	// 파일
+	check(strings.TrimSpace(c.File.UploadsDir) != "", "UPLOADS_DIR: 업로드 디렉토리를 지정해야 합니다")
+	check(c.File.MaxFileSize > 0, "MAX_FILE_SIZE_MB: 0보다 커야 합니다")
+	check(c.File.MaxFilesPerPost > 0, "MAX_FILES_PER_POST: 0보다 커야 합니다")
+	check(c.File.MaxFolderFiles > 0, "MAX_FOLDER_FILES: 0보다 커야 합니다")
+	check(c.File.ThumbnailSize > 0, "THUMBNAIL_SIZE: 0보다 커야 합니다")
+
+	// 검사기, 작업, 댓글, 압축 파일
+	check(c.Scanner.Timeout > 0, "CLAMD_TIMEOUT_SEC: 0보다 커야 합니다")
+	check(c.Jobs.Workers > 0, "JOB_WORKERS: 0보다 커야 합니다")
+	check(c.Jobs.PollInterval > 0, "JOB_POLL_INTERVAL_SEC: 0보다 커야 합니다")
+	check(c.Jobs.VisibilityTimeout > 0, "JOB_VISIBILITY_TIMEOUT_SEC: 0보다 커야 합니다")
+	check(c.Jobs.MaxAttempts > 0, "JOB_MAX_ATTEMPTS: 0보다 커야 합니다")
+	check(c.Jobs.DoneRetention >= 0, "JOB_DONE_RETENTION_SEC: 0 이상이어야 합니다 (0이면 삭제하지 않음)")
+	check(c.Comment.MaxLength > 0, "COMMENT_MAX_LENGTH: 0보다 커야 합니다")
+	check(c.Comment.RateLimit >= 0, "COMMENT_RATE_LIMIT: 0 이상이어야 합니다 (0이면 제한 없음)")
+	check(c.Comment.RateWindow > 0, "COMMENT_RATE_WINDOW_SEC: 0보다 커야 합니다")
+	check(c.Archive.MaxEntries > 0, "ARCHIVE_MAX_ENTRIES: 0보다 커야 합니다")
+	check(c.Archive.MaxTotalSize > 0, "ARCHIVE_MAX_TOTAL_MB: 0보다 커야 합니다")
+	check(c.Archive.MaxEntrySize > 0, "ARCHIVE_MAX_ENTRY_MB: 0보다 커야 합니다")
+	check(c.Archive.MaxRatio > 0, "ARCHIVE_MAX_RATIO: 0보다 커야 합니다")
+
+	// 지표, 로그, 상태 확인
+	check(strings.HasPrefix(c.Metrics.Path, "/"), "METRICS_PATH: /로 시작해야 합니다 (%q)", c.Metrics.Path)
+	switch strings.ToLower(c.Log.Format) {
+	case "json", "text":
+	default:
+		errs = append(errs, fmt.Errorf("LOG_FORMAT: json 또는 text여야 합니다 (%q)", c.Log.Format))
+	}
+	var level slog.Level
+	check(level.UnmarshalText([]byte(c.Log.Level)) == nil, "LOG_LEVEL: debug, info, warn, error 중 하나여야 합니다 (%q)", c.Log.Level)
+	check(c.Health.CheckTimeout > 0, "HEALTH_TIMEOUT_SEC: 0보다 커야 합니다")
+
+	if c.IsProduction() {
+		errs = append(errs, c.productionErrors()...)
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("설정 오류:\n%w", errors.Join(errs...))
+}
+
+// productionErrors 운영 모드에서 허용하지 않는 안전하지 않은 설정
+func (c *Config) productionErrors() []error {
+	var errs []error
+	password := c.Server.AdminPassword
+	if weakAdminPasswords[strings.ToLower(password)] || len(password) < minProductionPasswordLength {
+		errs = append(errs, fmt.Errorf("ADMIN_PASSWORD: 운영 모드에서는 %d자 이상의 추측하기 어려운 비밀번호가 필요합니다", minProductionPasswordLength))
+	}
+	if len(c.Server.SessionSecret) < minSessionSecretLength {
+		errs = append(errs, fmt.Errorf("SESSION_SECRET: 운영 모드에서는 %d자 이상의 세션 키가 필요합니다", minSessionSecretLength))
+	}
+	if c.Database.URL == "" && c.Database.Password == "" {
+		errs = append(errs, fmt.Errorf("DB_PASSWORD: 운영 모드에서는 데이터베이스 비밀번호가 필요합니다"))
+	}
+	if c.Metrics.Port != "" && c.Metrics.Token == "" {
+		errs = append(errs, fmt.Errorf("METRICS_TOKEN: 운영 모드에서 METRICS_PORT를 사용하면 토큰이 필요합니다"))
+	}
+	return errs
+}
+
+// validPort 1-65535 범위의 포트 번호인지 확인
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+// validIPOrCIDR IP 주소 또는 CIDR 표기인지 확인
+func validIPOrCIDR(entry string) bool {
+	if strings.Contains(entry, "/") {
+		_, _, err := net.ParseCIDR(entry)
+		return err == nil
+	}
+	return net.ParseIP(entry) != nil
+}
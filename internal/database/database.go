@@ -1,8 +1,11 @@
 package database
 
 import (
+	"context"
 	"database/sql"
-	"log"
+	"fmt"
+	"log/slog"
+	"time"
 
 	"file-board/internal/config"
 
@@ -13,24 +16,68 @@ type DB struct {
 	conn *sql.DB
 }
 
+// 시작 시 연결 재시도 간격 (실패할 때마다 두 배, 최대 maxRetryDelay)
+const (
+	initialRetryDelay = time.Second
+	maxRetryDelay     = 10 * time.Second
+)
+
+// New 연결 풀 설정 후 데이터베이스가 준비될 때까지 재시도 (docker-compose에서 함께 시작하는 경우 대비)
 func New(cfg *config.Config) (*DB, error) {
 	conn, err := sql.Open("postgres", cfg.GetDatabaseURL())
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("데이터베이스 설정 오류: %v", err)
 	}
 
-	if err := conn.Ping(); err != nil {
+	conn.SetMaxOpenConns(cfg.Database.MaxOpenConns)
+	conn.SetMaxIdleConns(cfg.Database.MaxIdleConns)
+	conn.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
+	conn.SetConnMaxIdleTime(cfg.Database.ConnMaxIdleTime)
+
+	if err := waitForDatabase(conn, cfg.Database.StartupTimeout); err != nil {
+		conn.Close()
 		return nil, err
 	}
 
-	log.Println("데이터베이스 연결 성공")
+	slog.Info("데이터베이스 연결 성공",
+		"max_open_conns", cfg.Database.MaxOpenConns, "max_idle_conns", cfg.Database.MaxIdleConns)
 	return &DB{conn: conn}, nil
 }
 
+// waitForDatabase 연결될 때까지 지수 백오프로 재시도 (timeout이 지나면 마지막 에러 반환)
+func waitForDatabase(conn *sql.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	delay := initialRetryDelay
+	for attempt := 1; ; attempt++ {
+		err := conn.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("%s 동안 데이터베이스에 연결하지 못했습니다: %v", timeout, err)
+		}
+
+		slog.Warn("데이터베이스 연결 실패, 재시도", "attempt", attempt, "retry_in", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s 동안 데이터베이스에 연결하지 못했습니다: %v", timeout, err)
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxRetryDelay)
+	}
+}
+
 func (db *DB) Close() error {
 	return db.conn.Close()
 }
 
+// Ping 데이터베이스 연결 확인
+func (db *DB) Ping(ctx context.Context) error {
+	return db.conn.PingContext(ctx)
+}
+
 func (db *DB) GetConnection() *sql.DB {
 	return db.conn
 }
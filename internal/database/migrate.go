@@ -0,0 +1,138 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// 마이그레이션 SQL 파일 (파일명 순서대로 적용)
+//
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// 동시에 여러 인스턴스가 마이그레이션하지 않도록 사용하는 advisory lock 키
+const migrationLockKey = 20250811
+
+// Migrate 아직 적용되지 않은 마이그레이션을 순서대로 적용
+//
+// db/init.sql은 최초 컨테이너 생성 시에만 실행되므로, 이후 추가되는 스키마
+// 변경은 모두 migrations 디렉토리에 추가하여 기존 데이터베이스에도 반영한다.
+func (db *DB) Migrate() error {
+	if _, err := db.conn.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version VARCHAR(255) PRIMARY KEY,
+			applied_at TIMESTAMPTZ DEFAULT NOW()
+		)
+	`); err != nil {
+		return fmt.Errorf("마이그레이션 테이블 생성 실패: %v", err)
+	}
+
+	names, err := migrationNames()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if err := db.applyMigration(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyMigration 단일 마이그레이션을 트랜잭션 안에서 적용 (이미 적용된 경우 건너뜀)
+func (db *DB) applyMigration(name string) error {
+	tx, err := db.conn.Begin()
+	if err != nil {
+		return fmt.Errorf("마이그레이션 트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("마이그레이션 잠금 실패: %v", err)
+	}
+
+	var applied bool
+	if err := tx.QueryRow(
+		"SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", name,
+	).Scan(&applied); err != nil {
+		return fmt.Errorf("마이그레이션 상태 확인 실패: %v", err)
+	}
+	if applied {
+		return nil
+	}
+
+	script, err := migrationFS.ReadFile("migrations/" + name)
+	if err != nil {
+		return fmt.Errorf("마이그레이션 파일 읽기 실패 (%s): %v", name, err)
+	}
+	if _, err := tx.Exec(string(script)); err != nil {
+		return fmt.Errorf("마이그레이션 적용 실패 (%s): %v", name, err)
+	}
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", name); err != nil {
+		return fmt.Errorf("마이그레이션 기록 실패 (%s): %v", name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("마이그레이션 커밋 실패 (%s): %v", name, err)
+	}
+
+	slog.Info("마이그레이션 적용", "migration", name)
+	return nil
+}
+
+// PendingMigrations 내장되어 있지만 아직 적용되지 않은 마이그레이션 목록
+func (db *DB) PendingMigrations(ctx context.Context) ([]string, error) {
+	names, err := migrationNames()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.conn.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("마이그레이션 상태 확인 실패: %v", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]bool)
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("마이그레이션 상태 확인 실패: %v", err)
+		}
+		applied[version] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("마이그레이션 상태 확인 실패: %v", err)
+	}
+
+	var pending []string
+	for _, name := range names {
+		if !applied[name] {
+			pending = append(pending, name)
+		}
+	}
+	return pending, nil
+}
+
+// migrationNames 내장된 마이그레이션 파일명 목록 (정렬됨)
+func migrationNames() ([]string, error) {
+	entries, err := fs.ReadDir(migrationFS, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("마이그레이션 목록 읽기 실패: %v", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".sql") {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
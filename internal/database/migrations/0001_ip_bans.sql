@@ -0,0 +1,20 @@
+-- IP/CIDR 차단 목록
+CREATE TABLE IF NOT EXISTS ip_bans (
+    id SERIAL PRIMARY KEY,
+    cidr CIDR NOT NULL,                    -- 차단 대역 (단일 IP는 /32, /128)
+    reason TEXT,                           -- 차단 사유
+    expires_at TIMESTAMPTZ,                -- 만료 시각 (NULL이면 영구 차단)
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ip_bans_cidr ON ip_bans USING gist (cidr inet_ops);
+CREATE INDEX IF NOT EXISTS idx_posts_ip_address ON posts(ip_address);
+
+-- 문자열을 inet으로 변환 (주소가 아니면 캐스팅 오류 대신 NULL 반환, posts.ip_address 대역 검색용)
+CREATE OR REPLACE FUNCTION try_inet(value TEXT) RETURNS INET AS $$
+BEGIN
+    RETURN value::inet;
+EXCEPTION WHEN invalid_text_representation THEN
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE STRICT;
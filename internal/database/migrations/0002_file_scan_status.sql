@@ -0,0 +1,7 @@
+-- 업로드 파일 악성코드 검사 상태
+ALTER TABLE files ADD COLUMN IF NOT EXISTS scan_status VARCHAR(20) NOT NULL DEFAULT 'unscanned'
+    CHECK (scan_status IN ('unscanned', 'pending', 'clean', 'infected', 'error'));
+ALTER TABLE files ADD COLUMN IF NOT EXISTS scan_signature VARCHAR(255); -- 탐지된 시그니처 이름
+ALTER TABLE files ADD COLUMN IF NOT EXISTS scanned_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_files_scan_status ON files(scan_status);
@@ -0,0 +1,2 @@
+-- 이미지 썸네일 생성 여부 (썸네일 파일은 file_hash 기준으로 저장되어 중복 파일끼리 공유)
+ALTER TABLE files ADD COLUMN IF NOT EXISTS has_thumbnail BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,18 @@
+-- 게시글 첨부 파일 (게시글 하나에 여러 파일, 파일 실체는 files 테이블에서 중복 제거)
+CREATE TABLE IF NOT EXISTS post_files (
+    id SERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    file_id INTEGER NOT NULL REFERENCES files(id),
+    file_name VARCHAR(255) NOT NULL,       -- 사용자가 업로드한 원본 파일명
+    position INTEGER NOT NULL DEFAULT 0,   -- 업로드 순서 (0부터)
+    UNIQUE (post_id, position)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_files_file_id ON post_files(file_id);
+
+-- 기존 단일 파일 게시글을 첫 번째 첨부 파일로 이전 (posts.file_id/file_name은 대표 파일로 유지)
+INSERT INTO post_files (post_id, file_id, file_name, position)
+SELECT p.id, p.file_id, COALESCE(p.file_name, ''), 0
+FROM posts p
+WHERE p.file_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM post_files pf WHERE pf.post_id = p.id);
@@ -0,0 +1,2 @@
+-- 폴더 업로드 시 폴더 기준 상대 경로 (예: design/icons/logo.png, 일반 업로드는 NULL)
+ALTER TABLE post_files ADD COLUMN IF NOT EXISTS relative_path TEXT;
@@ -0,0 +1,27 @@
+-- 압축 파일(ZIP/TAR) 색인 상태 (NULL이면 압축 파일 아님, pending/indexed/error)
+ALTER TABLE files ADD COLUMN IF NOT EXISTS archive_status VARCHAR(20);
+ALTER TABLE files ADD COLUMN IF NOT EXISTS archive_error TEXT;
+
+-- 압축 파일 항목 목록 (files 기준이므로 중복 파일끼리 공유)
+CREATE TABLE IF NOT EXISTS archive_entries (
+    id SERIAL PRIMARY KEY,
+    file_id INTEGER NOT NULL REFERENCES files(id) ON DELETE CASCADE,
+    entry_index INTEGER NOT NULL,          -- 압축 파일 안에서의 순서 (단일 항목 추출 시 식별자)
+    name TEXT NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,        -- 해제 후 크기
+    compressed_size BIGINT NOT NULL DEFAULT 0,
+    modified_at TIMESTAMPTZ,
+    is_dir BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (file_id, entry_index)
+);
+
+-- 기존에 업로드된 압축 파일도 색인 작업 예약
+UPDATE files SET archive_status = 'pending'
+WHERE archive_status IS NULL
+  AND mime_type IN ('application/zip', 'application/x-zip-compressed', 'application/x-tar', 'application/gzip', 'application/x-gzip')
+  AND scan_status <> 'infected';
+
+INSERT INTO jobs (job_type, payload)
+SELECT 'archive_index', jsonb_build_object('file_id', id)
+FROM files
+WHERE archive_status = 'pending';
@@ -0,0 +1,37 @@
+-- 게시판 (게시판별 페이지와 허용 게시글 종류, 최대 파일 크기 설정)
+CREATE TABLE IF NOT EXISTS boards (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(50) NOT NULL UNIQUE,          -- 주소에 쓰는 이름 (/boards/:slug)
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    allow_files BOOLEAN NOT NULL DEFAULT TRUE,
+    allow_messages BOOLEAN NOT NULL DEFAULT TRUE,
+    max_file_size BIGINT NOT NULL DEFAULT 0,   -- 바이트, 0이면 전체 설정(MAX_FILE_SIZE_MB) 사용
+    position INTEGER NOT NULL DEFAULT 0,       -- 목록 순서 (가장 앞의 게시판이 기본 게시판)
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- 기본 게시판 (기존 게시글은 모두 여기로)
+INSERT INTO boards (slug, name, description)
+SELECT 'general', '자유 게시판', '파일과 메시지를 자유롭게 올리는 게시판입니다.'
+WHERE NOT EXISTS (SELECT 1 FROM boards);
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS board_id INTEGER REFERENCES boards(id);
+UPDATE posts SET board_id = (SELECT id FROM boards ORDER BY position, id LIMIT 1) WHERE board_id IS NULL;
+ALTER TABLE posts ALTER COLUMN board_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_posts_board_created ON posts(board_id, created_at DESC);
+
+-- 태그 (게시글별 자유 입력, 이름은 소문자로 정규화)
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(30) NOT NULL UNIQUE
+);
+
+CREATE TABLE IF NOT EXISTS post_tags (
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (post_id, tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_tags_tag_id ON post_tags(tag_id);
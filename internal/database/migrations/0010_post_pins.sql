@@ -0,0 +1,14 @@
+-- 게시글 고정 및 공지 (관리자 전용)
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS pin_order INTEGER;                        -- NULL이면 고정되지 않음, 작을수록 위
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS is_announcement BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS announce_from TIMESTAMPTZ;                -- 공지 게시 시작 (NULL이면 즉시)
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS announce_until TIMESTAMPTZ;               -- 공지 게시 종료 (NULL이면 계속)
+
+CREATE INDEX IF NOT EXISTS idx_posts_board_pin ON posts(board_id, pin_order) WHERE pin_order IS NOT NULL;
+
+-- init.sql의 환영 메시지는 새 글에 밀리지 않도록 고정
+UPDATE posts SET pin_order = 0
+WHERE post_type = 'message'
+  AND title = '🌱 새싹 여러분 환영합니다! 오늘 하루도 행복하길 😊'
+  AND pin_order IS NULL
+  AND deleted_at IS NULL;
@@ -0,0 +1,12 @@
+-- 게시글 수정 이력 (관리자가 수정하기 직전의 제목/내용/파일명을 보관)
+CREATE TABLE IF NOT EXISTS post_revisions (
+    id SERIAL PRIMARY KEY,
+    post_id INTEGER NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    title VARCHAR(255),
+    content TEXT,
+    file_name VARCHAR(255),
+    edited_ip VARCHAR(45),                 -- 수정한 관리자 IP
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_revisions_post_id ON post_revisions(post_id, created_at DESC);
@@ -0,0 +1,6 @@
+-- 재시작 없이 바꿀 수 있는 운영 설정 (값은 JSON, 없는 키는 환경 설정 기본값 사용)
+CREATE TABLE IF NOT EXISTS settings (
+    key VARCHAR(100) PRIMARY KEY,
+    value JSONB NOT NULL,
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
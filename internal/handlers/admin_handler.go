@@ -1,11 +1,18 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"file-board/internal/config"
+	"file-board/internal/jobs"
 	"file-board/internal/middleware"
+	"file-board/internal/models"
 	"file-board/internal/services"
 
 	"github.com/gin-contrib/sessions"
@@ -13,14 +20,24 @@ import (
 )
 
 type AdminHandler struct {
-	postService *services.PostService
-	cfg         *config.Config
+	postService    *services.PostService
+	commentService *services.CommentService
+	banService     *services.BanService
+	boardService   *services.BoardService
+	settings       *services.SettingsService
+	jobQueue       *jobs.Queue
+	cfg            *config.Config
 }
 
-func NewAdminHandler(postService *services.PostService, cfg *config.Config) *AdminHandler {
+func NewAdminHandler(postService *services.PostService, commentService *services.CommentService, banService *services.BanService, boardService *services.BoardService, settings *services.SettingsService, jobQueue *jobs.Queue, cfg *config.Config) *AdminHandler {
 	return &AdminHandler{
-		postService: postService,
-		cfg:         cfg,
+		postService:    postService,
+		commentService: commentService,
+		banService:     banService,
+		boardService:   boardService,
+		settings:       settings,
+		jobQueue:       jobQueue,
+		cfg:            cfg,
 	}
 }
 
@@ -38,7 +55,7 @@ func (h *AdminHandler) setNoCacheHeaders(c *gin.Context) {
 	c.Header("Expires", "0")
 }
 
-// 관리자 메인 페이지 핸들러
+// 관리자 메인 페이지 핸들러 (board, tag 쿼리로 게시글 목록 필터링)
 func (h *AdminHandler) IndexHandler(c *gin.Context) {
 	// 권한 재확인
 	if !h.isAdminAuthenticated(c) {
@@ -49,14 +66,38 @@ func (h *AdminHandler) IndexHandler(c *gin.Context) {
 	// 캐시 방지 헤더 설정
 	h.setNoCacheHeaders(c)
 
-	posts, err := h.postService.GetAllPosts()
+	var filter models.PostFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.HTML(http.StatusBadRequest, "admin.html", gin.H{"error": "검색 조건이 올바르지 않습니다."})
+		return
+	}
+
+	posts, err := h.postService.GetAllPosts(c.Request.Context(), filter)
 	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "게시글을 불러올 수 없습니다.", "error", err)
 		c.HTML(http.StatusInternalServerError, "admin.html", gin.H{"error": "게시글을 불러올 수 없습니다."})
 		return
 	}
 
+	bans, err := h.banService.GetBans()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "차단 목록을 불러올 수 없습니다.", "error", err)
+		c.HTML(http.StatusInternalServerError, "admin.html", gin.H{"error": "차단 목록을 불러올 수 없습니다."})
+		return
+	}
+
+	boards, err := h.boardService.GetBoards()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "게시판 목록을 불러올 수 없습니다.", "error", err)
+		c.HTML(http.StatusInternalServerError, "admin.html", gin.H{"error": "게시판 목록을 불러올 수 없습니다."})
+		return
+	}
+
 	c.HTML(http.StatusOK, "admin.html", gin.H{
-		"posts": posts,
+		"posts":  posts,
+		"bans":   bans,
+		"boards": boards,
+		"filter": filter,
 	})
 }
 
@@ -64,23 +105,23 @@ func (h *AdminHandler) IndexHandler(c *gin.Context) {
 func (h *AdminHandler) DeletePostHandler(c *gin.Context) {
 	// 캐시 방지 헤더 설정
 	h.setNoCacheHeaders(c)
-	
+
 	// 권한 재확인
 	if !h.isAdminAuthenticated(c) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "관리자 권한이 필요합니다."})
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 게시글 ID"})
+		respondError(c, http.StatusBadRequest, "잘못된 게시글 ID")
 		return
 	}
 
-	err = h.postService.DeletePost(id)
+	err = h.postService.DeletePost(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -91,45 +132,411 @@ func (h *AdminHandler) DeletePostHandler(c *gin.Context) {
 func (h *AdminHandler) RestorePostHandler(c *gin.Context) {
 	// 캐시 방지 헤더 설정
 	h.setNoCacheHeaders(c)
-	
+
 	// 권한 재확인
 	if !h.isAdminAuthenticated(c) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "관리자 권한이 필요합니다."})
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
 		return
 	}
 
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 게시글 ID"})
+		respondError(c, http.StatusBadRequest, "잘못된 게시글 ID")
 		return
 	}
 
-	err = h.postService.RestorePost(id)
+	err = h.postService.RestorePost(c.Request.Context(), id)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "게시글이 복구되었습니다."})
 }
 
+// 게시글 일괄 처리 핸들러 (선택한 ID 목록 또는 쿼리 문자열의 검색 조건에 맞는 게시글 전체)
+func (h *AdminHandler) BulkPostsHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	var req models.BulkPostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "일괄 처리 요청 형식이 올바르지 않습니다.")
+		return
+	}
+
+	var filter *models.PostFilter
+	if req.AllMatching {
+		filter = &models.PostFilter{}
+		if err := c.ShouldBindQuery(filter); err != nil {
+			respondError(c, http.StatusBadRequest, "검색 조건 형식이 올바르지 않습니다.")
+			return
+		}
+	} else if len(req.IDs) == 0 {
+		respondError(c, http.StatusBadRequest, "처리할 게시글을 선택해주세요.")
+		return
+	}
+
+	results, err := h.postService.BulkUpdatePosts(c.Request.Context(), req.Action, req.IDs, filter)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	succeeded := 0
+	for _, result := range results {
+		if result.Success {
+			succeeded++
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   fmt.Sprintf("%d개 게시글 %s 완료 (%d개 건너뜀)", succeeded, services.BulkActionLabel(req.Action), len(results)-succeeded),
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+		"results":   results,
+	})
+}
+
+// 게시글 고정/공지 설정 핸들러
+func (h *AdminHandler) PinPostHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 게시글 ID")
+		return
+	}
+
+	var req models.PinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "고정 설정 형식이 올바르지 않습니다.")
+		return
+	}
+
+	err = h.postService.PinPost(c.Request.Context(), id, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(c, http.StatusNotFound, "게시글을 찾을 수 없거나 삭제된 게시글입니다.")
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.Announcement {
+		c.JSON(http.StatusOK, gin.H{"message": "공지로 고정되었습니다."})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "게시글이 고정되었습니다."})
+}
+
+// 게시글 고정 해제 핸들러
+func (h *AdminHandler) UnpinPostHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 게시글 ID")
+		return
+	}
+
+	if err := h.postService.UnpinPost(c.Request.Context(), id); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "고정이 해제되었습니다."})
+}
+
+// 게시글 수정 핸들러 (제목, 메시지 내용, 파일명)
+func (h *AdminHandler) EditPostHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 게시글 ID")
+		return
+	}
+
+	var req models.PostEditRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "수정 요청 형식이 올바르지 않습니다.")
+		return
+	}
+
+	err = h.postService.EditPost(c.Request.Context(), id, req, c.ClientIP())
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(c, http.StatusNotFound, "게시글을 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "게시글이 수정되었습니다."})
+}
+
+// 게시글 수정 이력 조회 핸들러
+func (h *AdminHandler) ListRevisionsHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 게시글 ID")
+		return
+	}
+
+	revisions, err := h.postService.GetRevisions(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+// 게시글 수정 이력으로 되돌리기 핸들러
+func (h *AdminHandler) RevertPostHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 게시글 ID")
+		return
+	}
+	revisionID, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 수정 이력 ID")
+		return
+	}
+
+	err = h.postService.RevertPost(c.Request.Context(), id, revisionID, c.ClientIP())
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(c, http.StatusNotFound, "수정 이력을 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "이전 내용으로 되돌렸습니다."})
+}
+
 // 통계 조회 핸들러
 func (h *AdminHandler) GetStatsHandler(c *gin.Context) {
 	// 캐시 방지 헤더 설정
 	h.setNoCacheHeaders(c)
-	
+
 	// 권한 재확인
 	if !h.isAdminAuthenticated(c) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "관리자 권한이 필요합니다."})
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
 		return
 	}
 
-	stats, err := h.postService.GetStats()
+	stats, err := h.postService.GetStats(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "통계 조회 실패"})
+		respondError(c, http.StatusInternalServerError, "통계 조회 실패")
 		return
 	}
 
 	c.JSON(http.StatusOK, stats)
 }
+
+// IP 차단 목록 조회 핸들러
+func (h *AdminHandler) ListBansHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	bans, err := h.banService.GetBans()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"bans": bans})
+}
+
+// IP 차단 추가 핸들러 (선택 시 해당 대역 게시글 일괄 삭제)
+func (h *AdminHandler) CreateBanHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	var req models.BanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "차단할 IP 또는 CIDR을 입력해주세요.")
+		return
+	}
+	if req.DurationHours < 0 {
+		respondError(c, http.StatusBadRequest, "차단 기간이 올바르지 않습니다.")
+		return
+	}
+
+	ban, err := h.banService.CreateBan(req.CIDR, req.Reason, time.Duration(req.DurationHours)*time.Hour)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := gin.H{
+		"message": ban.CIDR + " 대역이 차단되었습니다.",
+		"ban":     ban,
+	}
+
+	// 차단은 이미 저장되었으므로 게시글 삭제에 실패해도 성공으로 응답하고 오류를 함께 전달
+	if req.DeletePosts {
+		deletedPosts, err := h.postService.DeletePostsByCIDR(c.Request.Context(), ban.CIDR)
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "차단 대역 게시글 삭제 실패", "cidr", ban.CIDR, "error", err)
+			response["delete_error"] = "게시글 삭제에 실패했습니다: " + err.Error()
+		}
+		response["deleted_posts"] = deletedPosts
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// IP 차단 해제 핸들러
+func (h *AdminHandler) DeleteBanHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 차단 ID")
+		return
+	}
+
+	if err := h.banService.DeleteBan(id); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "차단이 해제되었습니다."})
+}
+
+// 작업 큐 페이지 핸들러
+func (h *AdminHandler) JobsHandler(c *gin.Context) {
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		c.Redirect(http.StatusFound, "/login")
+		return
+	}
+
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	status := c.Query("status")
+	switch status {
+	case "", models.JobStatusQueued, models.JobStatusRunning, models.JobStatusDone, models.JobStatusFailed:
+	default:
+		status = ""
+	}
+
+	stats, err := h.jobQueue.GetStats(c.Request.Context())
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "작업 통계를 불러올 수 없습니다.", "error", err)
+		c.HTML(http.StatusInternalServerError, "admin_jobs.html", gin.H{"error": "작업 통계를 불러올 수 없습니다."})
+		return
+	}
+
+	jobList, err := h.jobQueue.GetJobs(status, 100)
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "작업 목록을 불러올 수 없습니다.", "error", err)
+		c.HTML(http.StatusInternalServerError, "admin_jobs.html", gin.H{"error": "작업 목록을 불러올 수 없습니다."})
+		return
+	}
+
+	c.HTML(http.StatusOK, "admin_jobs.html", gin.H{
+		"jobs":   jobList,
+		"stats":  stats,
+		"status": status,
+	})
+}
+
+// 실패한 작업 재시도 핸들러
+func (h *AdminHandler) RetryJobHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 작업 ID")
+		return
+	}
+
+	if err := h.jobQueue.Retry(id); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "작업이 다시 대기열에 추가되었습니다."})
+}
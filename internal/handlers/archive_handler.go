@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"file-board/internal/models"
+	"file-board/internal/services"
+	"file-board/internal/zipstream"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errInvalidArchiveIDs   = errors.New("다운로드할 게시글을 선택해주세요.")
+	errTooManyArchivePosts = fmt.Errorf("한 번에 최대 %d개 게시글까지 다운로드할 수 있습니다.", services.MaxArchivePosts)
+)
+
+// 게시글 ZIP 다운로드 핸들러 (/archive/:id는 게시글 하나, /archive?ids=1,2,3은 선택한 게시글들)
+//
+// 게시글 하나를 받을 때 path를 지정하면 해당 폴더만 묶는다.
+func (h *Handler) ArchiveHandler(c *gin.Context) {
+	postIDs, err := archivePostIDs(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	dir, err := services.NormalizeFolder(c.Query("path"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	files, err := h.postService.GetArchiveFiles(c.Request.Context(), postIDs, false)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "파일 목록을 불러올 수 없습니다.")
+		return
+	}
+
+	name := archiveName(postIDs)
+	if dir != "" && len(postIDs) == 1 {
+		files = services.FilterFolder(files, dir)
+		name = path.Base(dir) + ".zip"
+	}
+
+	streamZip(c, files, name)
+}
+
+// 선택한 게시글 ZIP 다운로드 핸들러 (관리자용 - 삭제된 게시글 포함)
+func (h *AdminHandler) ArchiveHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	postIDs, err := archivePostIDs(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	files, err := h.postService.GetArchiveFiles(c.Request.Context(), postIDs, true)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	streamZip(c, files, archiveName(postIDs))
+}
+
+// 검색 조건에 맞는 게시글 전체 ZIP 내보내기 핸들러 (관리자용)
+func (h *AdminHandler) ExportHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	var filter models.PostFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		respondError(c, http.StatusBadRequest, "검색 조건이 올바르지 않습니다.")
+		return
+	}
+
+	files, err := h.postService.GetArchiveFilesByFilter(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	streamZip(c, files, "export-"+time.Now().Format("20060102-150405")+".zip")
+}
+
+// archivePostIDs 경로(:id) 또는 쿼리(ids=1,2,3)에서 게시글 ID 목록 해석
+func archivePostIDs(c *gin.Context) ([]int, error) {
+	raw := c.Param("id")
+	if raw == "" {
+		raw = c.Query("ids")
+	}
+
+	var postIDs []int
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, errInvalidArchiveIDs
+		}
+		if !seen[id] {
+			seen[id] = true
+			postIDs = append(postIDs, id)
+		}
+	}
+
+	if len(postIDs) == 0 {
+		return nil, errInvalidArchiveIDs
+	}
+	if len(postIDs) > services.MaxArchivePosts {
+		return nil, errTooManyArchivePosts
+	}
+	return postIDs, nil
+}
+
+// archiveName 다운로드 파일명
+func archiveName(postIDs []int) string {
+	if len(postIDs) == 1 {
+		return "post-" + strconv.Itoa(postIDs[0]) + ".zip"
+	}
+	return "posts-" + time.Now().Format("20060102-150405") + ".zip"
+}
+
+// streamZip 첨부 파일들을 ZIP으로 응답 본문에 바로 기록 (원본 파일명 사용, 중복 이름은 번호 부여)
+func streamZip(c *gin.Context, files []models.Attachment, fileName string) {
+	if len(files) == 0 {
+		respondError(c, http.StatusNotFound, "다운로드할 수 있는 파일이 없습니다.")
+		return
+	}
+
+	entries := make([]zipstream.Entry, 0, len(files))
+	for _, file := range files {
+		entries = append(entries, zipstream.Entry{
+			Name:     file.DisplayPath(),
+			Path:     file.File.FilePath,
+			MimeType: file.File.MimeType,
+			ModTime:  file.File.CreatedAt,
+		})
+	}
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	skipped, err := zipstream.Write(c.Writer, entries)
+	if len(skipped) > 0 {
+		slog.WarnContext(c.Request.Context(), "ZIP 생성 중 파일 누락", "archive", fileName, "skipped", skipped)
+	}
+	if err != nil {
+		// 이미 응답을 보내기 시작했으므로 로그만 남김 (클라이언트 연결 종료 등)
+		slog.WarnContext(c.Request.Context(), "ZIP 전송 중단", "archive", fileName, "error", err)
+	}
+}
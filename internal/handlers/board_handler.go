@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"file-board/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 게시판 목록 조회 핸들러 (관리자용)
+func (h *AdminHandler) ListBoardsHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	boards, err := h.boardService.GetBoards()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"boards": boards})
+}
+
+// 게시판 생성 핸들러
+func (h *AdminHandler) CreateBoardHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	var req models.BoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "게시판 주소 이름과 이름을 입력해주세요.")
+		return
+	}
+
+	board, err := h.boardService.CreateBoard(req)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": board.Name + " 게시판이 만들어졌습니다.", "board": board})
+}
+
+// 게시판 수정 핸들러
+func (h *AdminHandler) UpdateBoardHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 게시판 ID")
+		return
+	}
+
+	var req models.BoardRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "게시판 주소 이름과 이름을 입력해주세요.")
+		return
+	}
+
+	board, err := h.boardService.UpdateBoard(id, req)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(c, http.StatusNotFound, "게시판을 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": board.Name + " 게시판이 수정되었습니다.", "board": board})
+}
+
+// 게시판 삭제 핸들러 (게시글이 없는 게시판만 삭제 가능)
+func (h *AdminHandler) DeleteBoardHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 게시판 ID")
+		return
+	}
+
+	err = h.boardService.DeleteBoard(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		respondError(c, http.StatusNotFound, "게시판을 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "게시판이 삭제되었습니다."})
+}
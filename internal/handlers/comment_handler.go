@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"file-board/internal/models"
+	"file-board/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 게시글 댓글 목록 핸들러
+func (h *Handler) ListCommentsHandler(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 게시글 ID")
+		return
+	}
+
+	comments, err := h.commentService.GetComments(postID)
+	if errors.Is(err, services.ErrCommentPostNotFound) {
+		respondError(c, http.StatusNotFound, "게시글을 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "댓글을 불러올 수 없습니다.")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"comments": comments})
+}
+
+// 댓글 작성 핸들러 (parent_id 지정 시 답글)
+func (h *Handler) CreateCommentHandler(c *gin.Context) {
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 게시글 ID")
+		return
+	}
+
+	var req models.CommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "댓글 내용을 입력해주세요.")
+		return
+	}
+
+	comment, err := h.commentService.CreateComment(postID, req.ParentID, req.Content, c.ClientIP())
+	switch {
+	case errors.Is(err, services.ErrCommentRateLimited):
+		respondError(c, http.StatusTooManyRequests, err.Error())
+		return
+	case errors.Is(err, services.ErrCommentsDisabled):
+		respondError(c, http.StatusForbidden, err.Error())
+		return
+	case errors.Is(err, services.ErrCommentTargetNotFound):
+		respondError(c, http.StatusNotFound, err.Error())
+		return
+	case err != nil:
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "댓글이 등록되었습니다.", "comment": comment})
+}
+
+// 게시글 댓글 목록 핸들러 (관리자용 - 삭제된 댓글 포함)
+func (h *AdminHandler) ListCommentsHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	postID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 게시글 ID")
+		return
+	}
+
+	comments, err := h.commentService.GetAllComments(postID)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"comments": comments})
+}
+
+// 댓글 삭제 핸들러
+func (h *AdminHandler) DeleteCommentHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 댓글 ID")
+		return
+	}
+
+	if err := h.commentService.DeleteComment(id); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "댓글이 삭제되었습니다."})
+}
+
+// 댓글 복구 핸들러
+func (h *AdminHandler) RestoreCommentHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 댓글 ID")
+		return
+	}
+
+	if err := h.commentService.RestoreComment(id); err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "댓글이 복구되었습니다."})
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+
+	"file-board/internal/middleware"
+	"file-board/internal/models"
+	"file-board/internal/services"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler 상태 확인 핸들러 (사용자/관리자 서버 공통)
+type HealthHandler struct {
+	healthService *services.HealthService
+}
+
+func NewHealthHandler(healthService *services.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+// HealthzHandler 프로세스가 요청에 응답하는지 확인 (외부 의존성은 확인하지 않음)
+func (h *HealthHandler) HealthzHandler(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, gin.H{"status": models.HealthStatusOK})
+}
+
+// ReadyzHandler 요청을 처리할 준비가 되었는지 확인 (준비 안 되면 503, 항목별 결과는 관리자에게만)
+func (h *HealthHandler) ReadyzHandler(c *gin.Context) {
+	report := h.healthService.Ready(c.Request.Context())
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.Header("Cache-Control", "no-store")
+	if h.isAdmin(c) {
+		c.JSON(status, report)
+		return
+	}
+	c.JSON(status, gin.H{"status": report.Status})
+}
+
+// isAdmin 관리자 로그인 세션인지 확인 (세션 미들웨어가 없는 사용자 서버에서는 항상 false)
+func (h *HealthHandler) isAdmin(c *gin.Context) bool {
+	if _, ok := c.Get(sessions.DefaultKey); !ok {
+		return false
+	}
+	return sessions.Default(c).Get(middleware.AdminSessionKey) != nil
+}
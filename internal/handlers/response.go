@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"file-board/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// respondError JSON 에러 응답 (문의 시 로그를 찾을 수 있도록 요청 ID 포함)
+//
+// 5xx 응답의 메시지는 요청 로그에 함께 남도록 c.Errors에 추가한다.
+func respondError(c *gin.Context, status int, message string) {
+	if status >= http.StatusInternalServerError {
+		c.Error(errors.New(message))
+	}
+	c.JSON(status, gin.H{"error": message, "request_id": logging.RequestID(c.Request.Context())})
+}
+
+// requireJSON 상태를 바꾸는 요청의 Content-Type이 application/json인지 확인 (아니면 415 응답)
+//
+// 다른 사이트의 폼 전송은 이 형식으로 보낼 수 없으므로 SameSite 쿠키와 함께 CSRF를 막는다.
+func requireJSON(c *gin.Context) bool {
+	if c.ContentType() == gin.MIMEJSON {
+		return true
+	}
+	respondError(c, http.StatusUnsupportedMediaType, "Content-Type: application/json 요청이어야 합니다.")
+	return false
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 운영 설정 페이지 핸들러 (Accept: application/json이면 적용 중인 값, 기본값, 저장된 값을 JSON으로 반환)
+func (h *AdminHandler) SettingsHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	wantsJSON := c.NegotiateFormat(gin.MIMEHTML, gin.MIMEJSON) == gin.MIMEJSON
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		if wantsJSON {
+			respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		} else {
+			c.Redirect(http.StatusFound, "/login")
+		}
+		return
+	}
+
+	overrides, err := h.settings.Overrides(c.Request.Context())
+	if err != nil {
+		if wantsJSON {
+			respondError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "설정을 불러올 수 없습니다.", "error", err)
+		c.HTML(http.StatusInternalServerError, "admin_settings.html", gin.H{"error": "설정을 불러올 수 없습니다."})
+		return
+	}
+
+	if wantsJSON {
+		c.JSON(http.StatusOK, gin.H{
+			"settings":  h.settings.Current(),
+			"defaults":  h.settings.Defaults(),
+			"overrides": overrides,
+		})
+		return
+	}
+
+	// 기본값에서 바뀐 설정 키 (페이지에서 "기본값으로" 버튼 표시)
+	overridden := make([]string, 0, len(overrides))
+	for key := range overrides {
+		overridden = append(overridden, key)
+	}
+	sort.Strings(overridden)
+
+	c.HTML(http.StatusOK, "admin_settings.html", gin.H{
+		"settings":   h.settings.Current(),
+		"defaults":   h.settings.Defaults(),
+		"overridden": overridden,
+	})
+}
+
+// 운영 설정 변경 핸들러 (보낸 키만 변경, 값이 null이면 기본값으로 되돌림)
+func (h *AdminHandler) UpdateSettingsHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+	if !requireJSON(c) {
+		return
+	}
+
+	var changes map[string]json.RawMessage
+	if err := c.ShouldBindJSON(&changes); err != nil || len(changes) == 0 {
+		respondError(c, http.StatusBadRequest, "변경할 설정을 입력해주세요.")
+		return
+	}
+
+	settings, err := h.settings.Update(c.Request.Context(), changes)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "설정이 적용되었습니다.", "settings": settings})
+}
+
+// 운영 설정 다시 읽기 핸들러 (DB에서 직접 바꾼 값 적용)
+func (h *AdminHandler) ReloadSettingsHandler(c *gin.Context) {
+	// 캐시 방지 헤더 설정
+	h.setNoCacheHeaders(c)
+
+	// 권한 재확인
+	if !h.isAdminAuthenticated(c) {
+		respondError(c, http.StatusUnauthorized, "관리자 권한이 필요합니다.")
+		return
+	}
+	if !requireJSON(c) {
+		return
+	}
+
+	settings, err := h.settings.Reload(c.Request.Context())
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "설정을 다시 읽었습니다.", "settings": settings})
+}
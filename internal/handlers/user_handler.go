@@ -1,11 +1,19 @@
 package handlers
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"mime"
 	"net/http"
 	"os"
+	"path"
 	"strconv"
 
+	"file-board/internal/archive"
 	"file-board/internal/config"
+	"file-board/internal/markdown"
 	"file-board/internal/models"
 	"file-board/internal/services"
 
@@ -13,51 +21,196 @@ import (
 )
 
 type Handler struct {
-	postService *services.PostService
-	cfg         *config.Config
+	postService    *services.PostService
+	commentService *services.CommentService
+	boardService   *services.BoardService
+	settings       *services.SettingsService
+	cfg            *config.Config
+	includeDeleted bool // 삭제된 게시글의 파일도 제공 (관리자 서버)
 }
 
-func NewHandler(postService *services.PostService, cfg *config.Config) *Handler {
+func NewHandler(postService *services.PostService, commentService *services.CommentService, boardService *services.BoardService, settings *services.SettingsService, cfg *config.Config) *Handler {
 	return &Handler{
-		postService: postService,
-		cfg:         cfg,
+		postService:    postService,
+		commentService: commentService,
+		boardService:   boardService,
+		settings:       settings,
+		cfg:            cfg,
 	}
 }
 
-// 메인 페이지 핸들러
+// IncludingDeleted 삭제된 게시글의 파일도 제공하는 핸들러 (관리자 서버에서 파일 핸들러를 재사용할 때)
+func (h *Handler) IncludingDeleted() *Handler {
+	admin := *h
+	admin.includeDeleted = true
+	return &admin
+}
+
+// 메인 페이지 핸들러 (기본 게시판)
 func (h *Handler) IndexHandler(c *gin.Context) {
-	posts, err := h.postService.GetPosts()
+	h.renderBoard(c, "")
+}
+
+// 게시판 페이지 핸들러 (tag 쿼리로 태그별 보기)
+func (h *Handler) BoardHandler(c *gin.Context) {
+	h.renderBoard(c, c.Param("slug"))
+}
+
+// renderBoard 게시판 목록과 게시글을 index.html로 렌더링 (업로드 제한은 게시판 설정 적용)
+func (h *Handler) renderBoard(c *gin.Context, slug string) {
+	boards, err := h.boardService.GetBoards()
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "게시판을 불러올 수 없습니다.", "error", err)
+		c.HTML(http.StatusInternalServerError, "index.html", gin.H{"error": "게시판을 불러올 수 없습니다."})
+		return
+	}
+
+	board, err := h.boardService.ResolveBoard(slug)
+	if err == sql.ErrNoRows {
+		c.HTML(http.StatusNotFound, "index.html", gin.H{"error": "게시판을 찾을 수 없습니다.", "boards": boards})
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "게시판을 불러올 수 없습니다.", "error", err)
+		c.HTML(http.StatusInternalServerError, "index.html", gin.H{"error": "게시판을 불러올 수 없습니다.", "boards": boards})
+		return
+	}
+
+	settings := h.settings.Current()
+	data := gin.H{
+		"boards":        boards,
+		"board":         board,
+		"tag":           c.Query("tag"),
+		"maxFileSize":   board.MaxFileSizeText(settings.MaxFileSize()),
+		"maxFileSizeMB": board.EffectiveMaxFileSize(settings.MaxFileSize()) / (1024 * 1024),
+	}
+
+	posts, err := h.postService.GetPosts(c.Request.Context(), board.Slug, c.Query("tag"))
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "게시글을 불러올 수 없습니다.", "error", err)
+		data["error"] = "게시글을 불러올 수 없습니다."
+		c.HTML(http.StatusInternalServerError, "index.html", data)
+		return
+	}
+
+	data["posts"] = posts
+	c.HTML(http.StatusOK, "index.html", data)
+}
+
+// resolveUploadTarget 업로드 요청의 게시판과 태그 확인 (실패 시 응답을 보내고 false 반환)
+func (h *Handler) resolveUploadTarget(c *gin.Context, boardSlug, rawTags string) (*models.Board, []string, bool) {
+	board, err := h.boardService.ResolveBoard(boardSlug)
+	if err == sql.ErrNoRows {
+		respondError(c, http.StatusBadRequest, "게시판을 찾을 수 없습니다.")
+		return nil, nil, false
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "게시판을 불러올 수 없습니다.")
+		return nil, nil, false
+	}
+
+	tags, err := services.ParseTags(rawTags)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, err.Error())
+		return nil, nil, false
+	}
+	return board, tags, true
+}
+
+// 게시글 페이지 핸들러 (첨부 파일은 폴더 단위로 탐색, path 쿼리로 하위 폴더 지정)
+func (h *Handler) PostHandler(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "index.html", gin.H{
-			"error": "게시글을 불러올 수 없습니다.",
-			"maxFileSize": h.cfg.GetMaxFileSizeText(),
-			"maxFileSizeMB": h.cfg.GetMaxFileSizeMB(),
-		})
+		c.HTML(http.StatusBadRequest, "post.html", gin.H{"error": "잘못된 게시글 ID"})
 		return
 	}
 
-	c.HTML(http.StatusOK, "index.html", gin.H{
-		"posts": posts,
-		"maxFileSize": h.cfg.GetMaxFileSizeText(),
-		"maxFileSizeMB": h.cfg.GetMaxFileSizeMB(),
+	dir, err := services.NormalizeFolder(c.Query("path"))
+	if err != nil {
+		c.HTML(http.StatusBadRequest, "post.html", gin.H{"error": "잘못된 폴더 경로입니다."})
+		return
+	}
+
+	post, err := h.postService.GetPost(c.Request.Context(), id)
+	if err == sql.ErrNoRows {
+		c.HTML(http.StatusNotFound, "post.html", gin.H{"error": "게시글을 찾을 수 없습니다."})
+		return
+	}
+	if err != nil {
+		slog.ErrorContext(c.Request.Context(), "게시글을 불러올 수 없습니다.", "error", err)
+		c.HTML(http.StatusInternalServerError, "post.html", gin.H{"error": "게시글을 불러올 수 없습니다."})
+		return
+	}
+
+	folder := services.ListFolder(post.Attachments, dir)
+	if dir != "" && len(folder.Folders) == 0 && len(folder.Files) == 0 {
+		c.HTML(http.StatusNotFound, "post.html", gin.H{"error": "폴더를 찾을 수 없습니다."})
+		return
+	}
+
+	c.HTML(http.StatusOK, "post.html", gin.H{
+		"post":   post,
+		"folder": folder,
 	})
 }
 
-// 파일 업로드 핸들러
+// 파일 업로드 핸들러 (여러 "file" 파트를 하나의 게시글로 저장, 폴더 업로드는 같은 순서의 "path" 파트로 상대 경로 전달)
 func (h *Handler) UploadFileHandler(c *gin.Context) {
-	file, header, err := c.Request.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "파일을 선택해주세요."})
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["file"]) == 0 {
+		respondError(c, http.StatusBadRequest, "파일을 선택해주세요.")
+		return
+	}
+	files := form.File["file"]
+	paths := form.Value["path"]
+
+	settings := h.settings.Current()
+	maxFiles := settings.MaxFilesPerPost
+	if len(paths) > 0 {
+		maxFiles = settings.MaxFolderFiles
+	}
+	if len(files) > maxFiles {
+		respondError(c, http.StatusBadRequest, "파일은 한 번에 "+strconv.Itoa(maxFiles)+"개까지 업로드할 수 있습니다.")
+		return
+	}
+	if len(paths) > 0 && len(paths) != len(files) {
+		respondError(c, http.StatusBadRequest, "파일 경로 정보가 올바르지 않습니다.")
+		return
+	}
+
+	board, tags, ok := h.resolveUploadTarget(c, c.PostForm("board"), c.PostForm("tags"))
+	if !ok {
 		return
 	}
-	defer file.Close()
 
 	title := c.PostForm("title")
 	ipAddress := c.ClientIP()
 
-	err = h.postService.CreateFilePost(title, header, ipAddress)
+	err = h.postService.CreateFilePost(c.Request.Context(), board, title, tags, files, paths, ipAddress)
+	if errors.Is(err, context.Canceled) {
+		// 업로드 처리 중 연결이 끊겼거나 서버가 종료됨 (응답을 받을 클라이언트가 없음)
+		slog.WarnContext(c.Request.Context(), "파일 업로드 취소", "files", len(files))
+		c.Abort()
+		return
+	}
+	if errors.Is(err, services.ErrBoardPostTypeNotAllowed) {
+		respondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrUploadsDisabled) {
+		respondError(c, http.StatusForbidden, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrUploadQuotaExceeded) {
+		respondError(c, http.StatusTooManyRequests, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrFileQuarantined) {
+		respondError(c, http.StatusUnprocessableEntity, "악성코드가 탐지되어 파일이 격리되었습니다.")
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, http.StatusInternalServerError, err.Error())
 		return
 	}
 
@@ -67,63 +220,195 @@ func (h *Handler) UploadFileHandler(c *gin.Context) {
 // 메시지 업로드 핸들러
 func (h *Handler) UploadMessageHandler(c *gin.Context) {
 	// JSON과 Form-data 모두 처리
-	var title, content string
-	
+	var title, content, boardSlug, rawTags string
+
 	// Content-Type 확인
 	contentType := c.GetHeader("Content-Type")
 	if contentType == "application/json" {
 		// JSON 요청 처리 (API)
 		var req models.MessageUploadRequest
 		if err := c.ShouldBindJSON(&req); err != nil {
-			c.JSON(http.StatusBadRequest, gin.H{"error": "제목과 내용을 입력해주세요."})
+			respondError(c, http.StatusBadRequest, "제목과 내용을 입력해주세요.")
 			return
 		}
 		title = req.Title
 		content = req.Content
+		boardSlug = req.Board
+		rawTags = req.Tags
 	} else {
 		// Form-data 요청 처리 (웹 폼)
 		title = c.PostForm("title")
 		content = c.PostForm("content")
+		boardSlug = c.PostForm("board")
+		rawTags = c.PostForm("tags")
 	}
 
 	// 제목은 필수, 내용은 선택사항
 	if title == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "제목을 입력해주세요."})
+		respondError(c, http.StatusBadRequest, "제목을 입력해주세요.")
+		return
+	}
+
+	board, tags, ok := h.resolveUploadTarget(c, boardSlug, rawTags)
+	if !ok {
 		return
 	}
 
 	ipAddress := c.ClientIP()
 
-	err := h.postService.CreateMessagePost(title, content, ipAddress)
+	err := h.postService.CreateMessagePost(c.Request.Context(), board, title, content, tags, ipAddress)
+	if errors.Is(err, services.ErrBoardPostTypeNotAllowed) {
+		respondError(c, http.StatusForbidden, err.Error())
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "메시지 업로드 실패"})
+		respondError(c, http.StatusInternalServerError, "메시지 업로드 실패")
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "메시지 업로드 성공"})
 }
 
-// 파일 다운로드 핸들러
+// 파일 다운로드 핸들러 (/download/:id는 첫 번째 첨부 파일, /download/:id/:n은 n번째 첨부 파일)
 func (h *Handler) DownloadFileHandler(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.Atoi(idStr)
+	id, position, err := attachmentParams(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "잘못된 파일 ID"})
+		respondError(c, http.StatusBadRequest, "잘못된 파일 ID")
 		return
 	}
 
-	fileName, filePath, err := h.postService.GetFileInfo(id)
+	fileName, filePath, err := h.postService.GetFileInfo(c.Request.Context(), id, position, h.includeDeleted)
+	if errors.Is(err, services.ErrFileQuarantined) {
+		respondError(c, http.StatusForbidden, "악성코드가 탐지되어 다운로드할 수 없는 파일입니다.")
+		return
+	}
+	if errors.Is(err, services.ErrFileScanPending) {
+		respondError(c, http.StatusConflict, "악성코드 검사 중인 파일입니다. 잠시 후 다시 시도해주세요.")
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "파일을 찾을 수 없습니다."})
+		respondError(c, http.StatusNotFound, "파일을 찾을 수 없습니다.")
 		return
 	}
 
 	// 파일 존재 확인
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		c.JSON(http.StatusNotFound, gin.H{"error": "파일이 존재하지 않습니다."})
+		respondError(c, http.StatusNotFound, "파일이 존재하지 않습니다.")
 		return
 	}
 
 	c.Header("Content-Disposition", "attachment; filename="+fileName)
 	c.File(filePath)
 }
+
+// 압축 파일 항목 다운로드 핸들러 (/download/:id/:n/entries/:entry, 압축 파일 전체를 풀지 않고 항목 하나만 해제)
+func (h *Handler) ArchiveEntryHandler(c *gin.Context) {
+	id, position, err := attachmentParams(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 파일 ID")
+		return
+	}
+	entryIndex, err := strconv.Atoi(c.Param("entry"))
+	if err != nil || entryIndex < 0 {
+		respondError(c, http.StatusBadRequest, "잘못된 항목 번호")
+		return
+	}
+
+	entry, file, err := h.postService.GetArchiveEntry(c.Request.Context(), id, position, entryIndex, h.includeDeleted)
+	if errors.Is(err, services.ErrArchiveNotIndexed) {
+		respondError(c, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		status, message := fileErrorResponse(err)
+		respondError(c, status, message)
+		return
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(entry.Name))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": path.Base(entry.Name)}))
+	c.Header("Content-Length", strconv.FormatInt(entry.Size, 10))
+	c.Header("X-Content-Type-Options", "nosniff")
+
+	if err := h.postService.ExtractArchiveEntry(file, entry.Index, c.Writer); err != nil {
+		if c.Writer.Written() {
+			// 이미 응답을 보내기 시작했으므로 로그만 남김
+			slog.WarnContext(c.Request.Context(), "압축 파일 항목 전송 중단", "post_id", id, "entry", entryIndex, "error", err)
+			return
+		}
+		c.Header("Content-Length", "")
+		c.Header("Content-Disposition", "")
+		if errors.Is(err, archive.ErrLimitExceeded) {
+			respondError(c, http.StatusRequestEntityTooLarge, "압축 해제 제한을 넘는 항목입니다.")
+			return
+		}
+		slog.ErrorContext(c.Request.Context(), "압축 파일 항목 해제 실패", "post_id", id, "entry", entryIndex, "error", err)
+		respondError(c, http.StatusInternalServerError, "압축 파일 항목을 해제할 수 없습니다.")
+	}
+}
+
+// 썸네일 핸들러 (file_hash 기준이므로 내용이 바뀌지 않아 장기 캐시)
+func (h *Handler) ThumbnailHandler(c *gin.Context) {
+	fileHash := c.Param("hash")
+
+	thumbPath, err := h.postService.GetThumbnailPath(c.Request.Context(), fileHash, h.includeDeleted)
+	if err == sql.ErrNoRows {
+		respondError(c, http.StatusNotFound, "썸네일을 찾을 수 없습니다.")
+		return
+	}
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "썸네일 조회 실패")
+		return
+	}
+
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("ETag", `"`+fileHash+`"`)
+	c.Header("Content-Type", "image/jpeg")
+	c.File(thumbPath)
+}
+
+// 마크다운 미리보기 최대 길이
+const maxMarkdownPreviewBytes = 64 * 1024
+
+// 마크다운 미리보기 핸들러 (메시지 작성 폼에서 호출)
+func (h *Handler) MarkdownPreviewHandler(c *gin.Context) {
+	var content string
+	if c.GetHeader("Content-Type") == "application/json" {
+		var req models.MarkdownPreviewRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, "잘못된 요청입니다.")
+			return
+		}
+		content = req.Content
+	} else {
+		content = c.PostForm("content")
+	}
+
+	if len(content) > maxMarkdownPreviewBytes {
+		respondError(c, http.StatusRequestEntityTooLarge, "미리보기할 내용이 너무 깁니다.")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"html": markdown.Render(content)})
+}
+
+// attachmentParams 게시글 ID와 첨부 파일 순서 파라미터 해석 (순서가 없으면 첫 번째 파일)
+func attachmentParams(c *gin.Context) (int, int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	position := 0
+	if n := c.Param("n"); n != "" {
+		position, err = strconv.Atoi(n)
+		if err != nil || position < 0 {
+			return 0, 0, errors.New("잘못된 첨부 파일 순서")
+		}
+	}
+	return id, position, nil
+}
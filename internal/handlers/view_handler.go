@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"unicode/utf8"
+
+	"file-board/internal/markdown"
+	"file-board/internal/preview"
+	"file-board/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 파일 미리보기 페이지 핸들러 (판별된 MIME 타입에 따라 렌더링 방식 결정)
+func (h *Handler) ViewFileHandler(c *gin.Context) {
+	id, position, err := attachmentParams(c)
+	if err != nil {
+		c.HTML(http.StatusBadRequest, "view.html", gin.H{"error": "잘못된 파일 ID"})
+		return
+	}
+
+	post, err := h.postService.GetFilePost(c.Request.Context(), id, position, h.includeDeleted)
+	if err != nil {
+		status, message := fileErrorResponse(err)
+		c.HTML(status, "view.html", gin.H{"error": message})
+		return
+	}
+
+	kind := preview.Detect(post.File.MimeType, post.FileName)
+	data := gin.H{
+		"post":     post,
+		"position": position,
+		"kind":     string(kind),
+	}
+
+	switch kind {
+	case preview.KindText, preview.KindCode, preview.KindMarkdown:
+		text, truncated, err := readTextPreview(post.File.FilePath)
+		if err != nil {
+			c.HTML(http.StatusNotFound, "view.html", gin.H{"error": "파일이 존재하지 않습니다."})
+			return
+		}
+		if !utf8.ValidString(text) {
+			// 텍스트가 아닌 내용은 다운로드로 대체
+			data["kind"] = string(preview.KindDownload)
+			break
+		}
+		data["truncated"] = truncated
+
+		switch kind {
+		case preview.KindMarkdown:
+			data["html"] = markdown.Render(text)
+		case preview.KindCode:
+			code, css, err := preview.Highlight(text, post.FileName)
+			if err != nil {
+				data["kind"] = string(preview.KindText)
+				data["text"] = text
+				break
+			}
+			data["html"] = code
+			data["css"] = css
+		default:
+			data["text"] = text
+		}
+	}
+
+	c.HTML(http.StatusOK, "view.html", data)
+}
+
+// 원본 파일 인라인 제공 핸들러 (PDF/이미지/오디오/비디오 - Range 요청 지원)
+func (h *Handler) RawFileHandler(c *gin.Context) {
+	id, position, err := attachmentParams(c)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "잘못된 파일 ID")
+		return
+	}
+
+	post, err := h.postService.GetFilePost(c.Request.Context(), id, position, h.includeDeleted)
+	if err != nil {
+		status, message := fileErrorResponse(err)
+		respondError(c, status, message)
+		return
+	}
+
+	// 인라인 표시가 안전한 형식만 허용 (HTML, SVG 등은 다운로드로만 제공)
+	if !preview.Detect(post.File.MimeType, post.FileName).IsInline() {
+		c.Redirect(http.StatusFound, "/download/"+strconv.Itoa(post.ID)+"/"+strconv.Itoa(position))
+		return
+	}
+
+	if _, err := os.Stat(post.File.FilePath); os.IsNotExist(err) {
+		respondError(c, http.StatusNotFound, "파일이 존재하지 않습니다.")
+		return
+	}
+
+	c.Header("Content-Type", post.File.MimeType)
+	c.Header("Content-Disposition", "inline")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, max-age=3600")
+	c.File(post.File.FilePath)
+}
+
+// readTextPreview 텍스트 미리보기용으로 파일 앞부분 읽기
+func readTextPreview(filePath string) (string, bool, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", false, err
+	}
+	defer file.Close()
+
+	buf, err := io.ReadAll(io.LimitReader(file, preview.MaxTextBytes+1))
+	if err != nil {
+		return "", false, err
+	}
+
+	truncated := len(buf) > preview.MaxTextBytes
+	if truncated {
+		buf = buf[:preview.MaxTextBytes]
+		// 잘린 위치가 멀티바이트 문자 중간이면 해당 문자 제거
+		for i := 0; i < utf8.UTFMax-1 && len(buf) > 0; i++ {
+			if r, size := utf8.DecodeLastRune(buf); r != utf8.RuneError || size > 1 {
+				break
+			}
+			buf = buf[:len(buf)-1]
+		}
+	}
+	return string(buf), truncated, nil
+}
+
+// fileErrorResponse 파일 조회 에러를 상태 코드와 메시지로 변환
+func fileErrorResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, services.ErrFileQuarantined):
+		return http.StatusForbidden, "악성코드가 탐지되어 다운로드할 수 없는 파일입니다."
+	case errors.Is(err, services.ErrFileScanPending):
+		return http.StatusConflict, "악성코드 검사 중인 파일입니다. 잠시 후 다시 시도해주세요."
+	case errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound, "파일을 찾을 수 없습니다."
+	default:
+		return http.StatusInternalServerError, "파일 정보를 불러올 수 없습니다."
+	}
+}
@@ -0,0 +1,442 @@
+// Package jobs PostgreSQL jobs 테이블 기반 백그라운드 작업 큐
+//
+// 워커는 SELECT ... FOR UPDATE SKIP LOCKED로 작업을 하나씩 가져가며,
+// 실행 중인 작업은 locked_until(가시성 타임아웃)까지 다른 워커에게 보이지 않는다.
+// 워커가 비정상 종료되면 타임아웃 이후 다른 워커가 작업을 다시 가져간다.
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand"
+	"sync"
+	"time"
+
+	"file-board/internal/config"
+	"file-board/internal/logging"
+	"file-board/internal/models"
+)
+
+// 작업 결과와 잠금 연장을 기록할 때의 제한 시간 (종료 중에도 기록하되 DB 장애 시 무한히 기다리지 않음)
+const recordTimeout = 10 * time.Second
+
+// 완료된 작업 기록을 정리하는 주기
+const purgeInterval = time.Hour
+
+// HandlerFunc 작업 처리 함수 (에러 반환 시 백오프 후 재시도)
+type HandlerFunc func(ctx context.Context, job *models.Job) error
+
+// FailureFunc 작업이 최종 실패로 기록된 뒤 호출되는 함수 (작업 대상의 상태를 마무리할 때 사용)
+//
+// 처리 함수가 마지막 시도에서 실패한 경우뿐 아니라, 워커가 비정상 종료되어
+// 가시성 타임아웃 이후 시도 횟수를 넘긴 경우처럼 처리 함수가 호출되지 않은 경우에도 호출된다.
+type FailureFunc func(ctx context.Context, job *models.Job, jobErr error)
+
+// Queue 작업 큐 및 워커 풀
+type Queue struct {
+	db  *sql.DB
+	cfg config.JobConfig
+
+	mu       sync.RWMutex
+	handlers map[string]HandlerFunc
+	failures map[string]FailureFunc
+
+	wake chan struct{} // 같은 프로세스에서 작업이 추가되면 대기 중인 워커를 깨움
+	wg   sync.WaitGroup
+}
+
+func NewQueue(db *sql.DB, cfg config.JobConfig) *Queue {
+	return &Queue{
+		db:       db,
+		cfg:      cfg,
+		handlers: make(map[string]HandlerFunc),
+		failures: make(map[string]FailureFunc),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Register 작업 종류별 처리 함수 등록 (Start 이전에 호출)
+func (q *Queue) Register(jobType string, handler HandlerFunc) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = handler
+}
+
+// RegisterWithFailure 처리 함수와 최종 실패 시 호출할 함수를 함께 등록 (Start 이전에 호출)
+func (q *Queue) RegisterWithFailure(jobType string, handler HandlerFunc, onFail FailureFunc) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = handler
+	q.failures[jobType] = onFail
+}
+
+// Enqueue 작업 추가
+func (q *Queue) Enqueue(jobType string, payload interface{}) (int64, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("작업 데이터 변환 실패: %v", err)
+	}
+
+	var id int64
+	err = q.db.QueryRow(`
+		INSERT INTO jobs (job_type, payload, max_attempts)
+		VALUES ($1, $2, $3) RETURNING id
+	`, jobType, string(data), q.cfg.MaxAttempts).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("작업 추가 실패: %v", err)
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return id, nil
+}
+
+// Start 워커 고루틴 시작 (ctx 취소 시 현재 작업을 마치고 종료)
+func (q *Queue) Start(ctx context.Context) {
+	workers := q.cfg.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		q.wg.Add(1)
+		go q.work(ctx, i+1)
+	}
+	if q.cfg.DoneRetention > 0 {
+		q.wg.Add(1)
+		go q.runPurge(ctx)
+	}
+	slog.Info("작업 워커 시작", "workers", workers)
+}
+
+// Wait 모든 워커 종료 대기
+func (q *Queue) Wait() {
+	q.wg.Wait()
+}
+
+// work 워커 루프
+func (q *Queue) work(ctx context.Context, workerID int) {
+	defer q.wg.Done()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		job, err := q.claim()
+		if err != nil {
+			slog.ErrorContext(ctx, "작업 가져오기 실패", "worker", workerID, "error", err)
+		}
+		if job != nil {
+			q.run(ctx, job)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-time.After(q.cfg.PollInterval):
+		}
+	}
+}
+
+// claim 실행 가능한 작업 하나를 잠그고 가져옴 (대기 중이거나 가시성 타임아웃이 지난 작업)
+func (q *Queue) claim() (*models.Job, error) {
+	var job models.Job
+	err := q.db.QueryRow(`
+		UPDATE jobs
+		SET status = 'running', attempts = attempts + 1,
+		    locked_until = NOW() + make_interval(secs => $1), updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE (status = 'queued' AND run_at <= NOW())
+			   OR (status = 'running' AND locked_until < NOW())
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, job_type, payload, status, attempts, max_attempts, run_at, created_at, updated_at
+	`, q.cfg.VisibilityTimeout.Seconds()).Scan(
+		&job.ID, &job.Type, &job.Payload, &job.Status, &job.Attempts, &job.MaxAttempts,
+		&job.RunAt, &job.CreatedAt, &job.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// run 작업 실행 및 결과 기록
+func (q *Queue) run(ctx context.Context, job *models.Job) {
+	// 작업 처리 중 남기는 로그에 작업 ID와 종류 포함
+	ctx = logging.With(ctx, slog.Int64("job_id", job.ID), slog.String("job_type", job.Type))
+
+	// 가시성 타임아웃이 지나 다시 가져온 작업이 시도 횟수를 넘은 경우
+	if job.Attempts > job.MaxAttempts {
+		q.finish(ctx, job, fmt.Errorf("가시성 타임아웃 초과로 최대 시도 횟수(%d)를 넘었습니다", job.MaxAttempts))
+		return
+	}
+
+	q.mu.RLock()
+	handler, ok := q.handlers[job.Type]
+	q.mu.RUnlock()
+	if !ok {
+		q.finish(ctx, job, fmt.Errorf("등록되지 않은 작업 종류: %s", job.Type))
+		return
+	}
+
+	// 실행 시간이 긴 작업은 주기적으로 잠금 시간을 연장
+	runCtx, cancel := context.WithCancel(ctx)
+	go q.heartbeat(runCtx, job)
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("작업 처리 중 panic: %v", r)
+			}
+		}()
+		return handler(runCtx, job)
+	}()
+	cancel()
+
+	q.finish(ctx, job, err)
+}
+
+// heartbeat 실행 중인 작업의 locked_until 연장 (다른 워커가 다시 가져간 작업이면 중단)
+func (q *Queue) heartbeat(ctx context.Context, job *models.Job) {
+	ticker := time.NewTicker(q.cfg.VisibilityTimeout / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			dbCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+			result, err := q.db.ExecContext(dbCtx, `
+				UPDATE jobs SET locked_until = NOW() + make_interval(secs => $3), updated_at = NOW()
+				WHERE id = $1 AND status = 'running' AND attempts = $2
+			`, job.ID, job.Attempts, q.cfg.VisibilityTimeout.Seconds())
+			cancel()
+			if err != nil {
+				slog.ErrorContext(ctx, "작업 잠금 연장 실패", "error", err)
+				continue
+			}
+			if n, err := result.RowsAffected(); err == nil && n == 0 {
+				slog.WarnContext(ctx, "작업 잠금을 잃었습니다 (가시성 타임아웃 초과)", "attempt", job.Attempts)
+				return
+			}
+		}
+	}
+}
+
+// finish 작업 결과 기록 (실패 시 남은 시도 횟수에 따라 재시도 예약 또는 실패 처리)
+//
+// 잠금이 만료되어 다른 워커가 다시 가져간 작업은 status와 attempts가 달라지므로
+// 늦게 끝난 워커의 결과는 기록하지 않는다. 종료 신호로 중단된 작업은 실패로 세지 않고
+// 시도 횟수를 되돌려 바로 다시 실행되도록 대기열에 넣는다.
+func (q *Queue) finish(ctx context.Context, job *models.Job, jobErr error) {
+	// 종료 중에도 결과는 기록되도록 취소를 전파하지 않음
+	dbCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), recordTimeout)
+	defer cancel()
+
+	var result sql.Result
+	var err error
+	final := false
+	switch {
+	case jobErr == nil:
+		result, err = q.db.ExecContext(dbCtx, `
+			UPDATE jobs
+			SET status = 'done', locked_until = NULL, last_error = NULL,
+			    updated_at = NOW(), finished_at = NOW()
+			WHERE id = $1 AND status = 'running' AND attempts = $2
+		`, job.ID, job.Attempts)
+	case ctx.Err() != nil && errors.Is(jobErr, context.Canceled):
+		slog.InfoContext(ctx, "종료로 중단된 작업을 대기열에 되돌림", "attempt", job.Attempts)
+		result, err = q.db.ExecContext(dbCtx, `
+			UPDATE jobs
+			SET status = 'queued', attempts = attempts - 1, locked_until = NULL,
+			    run_at = NOW(), updated_at = NOW()
+			WHERE id = $1 AND status = 'running' AND attempts = $2
+		`, job.ID, job.Attempts)
+	case job.Attempts < job.MaxAttempts:
+		delay := q.backoff(job.Attempts)
+		slog.WarnContext(ctx, "작업 실패, 재시도 예약",
+			"retry_in", delay.Round(time.Second), "attempt", job.Attempts, "max_attempts", job.MaxAttempts, "error", jobErr)
+		result, err = q.db.ExecContext(dbCtx, `
+			UPDATE jobs
+			SET status = 'queued', locked_until = NULL, last_error = $3,
+			    run_at = NOW() + make_interval(secs => $4), updated_at = NOW()
+			WHERE id = $1 AND status = 'running' AND attempts = $2
+		`, job.ID, job.Attempts, jobErr.Error(), delay.Seconds())
+	default:
+		final = true
+		slog.ErrorContext(ctx, "작업 최종 실패", "attempt", job.Attempts, "error", jobErr)
+		result, err = q.db.ExecContext(dbCtx, `
+			UPDATE jobs
+			SET status = 'failed', locked_until = NULL, last_error = $3,
+			    updated_at = NOW(), finished_at = NOW()
+			WHERE id = $1 AND status = 'running' AND attempts = $2
+		`, job.ID, job.Attempts, jobErr.Error())
+	}
+
+	if err != nil {
+		slog.ErrorContext(ctx, "작업 결과 기록 실패", "error", err)
+		return
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		slog.WarnContext(ctx, "다른 워커가 다시 가져간 작업이라 결과를 기록하지 않습니다", "attempt", job.Attempts)
+		return
+	}
+
+	if final {
+		q.mu.RLock()
+		onFail := q.failures[job.Type]
+		q.mu.RUnlock()
+		if onFail != nil {
+			// 종료 중에도 대상 상태는 마무리되도록 결과 기록과 같은 컨텍스트 사용
+			onFail(dbCtx, job, jobErr)
+		}
+	}
+}
+
+// PurgeDone 완료 후 보관 기간이 지난 작업 기록 삭제 (실패한 작업은 재시도할 수 있도록 남김)
+func (q *Queue) PurgeDone(ctx context.Context) (int64, error) {
+	result, err := q.db.ExecContext(ctx, `
+		DELETE FROM jobs
+		WHERE status = 'done' AND finished_at < NOW() - make_interval(secs => $1)
+	`, q.cfg.DoneRetention.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("완료된 작업 정리 실패: %v", err)
+	}
+	return result.RowsAffected()
+}
+
+// runPurge ctx가 취소될 때까지 주기적으로 완료된 작업 기록 정리
+func (q *Queue) runPurge(ctx context.Context) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := q.PurgeDone(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "완료된 작업 정리 실패", "error", err)
+		} else if deleted > 0 {
+			slog.InfoContext(ctx, "보관 기간이 지난 완료 작업 삭제", "jobs", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// backoff 지수 백오프 + 지터 (기본 지연 * 2^(시도-1), 최대 지연으로 제한)
+func (q *Queue) backoff(attempt int) time.Duration {
+	delay := q.cfg.RetryBaseDelay
+	for i := 1; i < attempt && delay < q.cfg.RetryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > q.cfg.RetryMaxDelay {
+		delay = q.cfg.RetryMaxDelay
+	}
+	// 동시에 실패한 작업들이 한꺼번에 재시도하지 않도록 최대 20% 지터 추가
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
+
+// GetJobs 상태별 작업 목록 조회 (status가 비어 있으면 전체, 최근 변경순)
+func (q *Queue) GetJobs(status string, limit int) ([]models.Job, error) {
+	rows, err := q.db.Query(`
+		SELECT id, job_type, payload, status, attempts, max_attempts, COALESCE(last_error, ''),
+		       run_at, created_at, updated_at, finished_at
+		FROM jobs
+		WHERE $1::text = '' OR status = $1
+		ORDER BY updated_at DESC
+		LIMIT $2
+	`, status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("작업 목록 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	var jobs []models.Job
+	for rows.Next() {
+		var job models.Job
+		if err := rows.Scan(
+			&job.ID, &job.Type, &job.Payload, &job.Status, &job.Attempts, &job.MaxAttempts, &job.LastError,
+			&job.RunAt, &job.CreatedAt, &job.UpdatedAt, &job.FinishedAt,
+		); err != nil {
+			return nil, fmt.Errorf("작업 목록 스캔 실패: %v", err)
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// GetStats 상태별 작업 수 조회
+func (q *Queue) GetStats(ctx context.Context) (*models.JobStats, error) {
+	rows, err := q.db.QueryContext(ctx, "SELECT status, COUNT(*) FROM jobs GROUP BY status")
+	if err != nil {
+		return nil, fmt.Errorf("작업 통계 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	stats := &models.JobStats{}
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("작업 통계 스캔 실패: %v", err)
+		}
+		switch status {
+		case models.JobStatusQueued:
+			stats.Queued = count
+		case models.JobStatusRunning:
+			stats.Running = count
+		case models.JobStatusDone:
+			stats.Done = count
+		case models.JobStatusFailed:
+			stats.Failed = count
+		}
+	}
+	return stats, rows.Err()
+}
+
+// Retry 실패한 작업을 즉시 다시 실행하도록 대기열에 넣음 (시도 횟수 초기화)
+func (q *Queue) Retry(id int64) error {
+	result, err := q.db.Exec(`
+		UPDATE jobs
+		SET status = 'queued', attempts = 0, run_at = NOW(), finished_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND status = 'failed'
+	`, id)
+	if err != nil {
+		return fmt.Errorf("작업 재시도 실패: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("재시도 결과 확인 실패: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("작업을 찾을 수 없거나 실패 상태가 아닙니다")
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
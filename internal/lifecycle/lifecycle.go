@@ -0,0 +1,96 @@
+// Package lifecycle 여러 HTTP 서버를 함께 시작하고 종료 신호에 맞춰 함께 종료
+//
+// 종료 시에는 새 연결을 받지 않고 진행 중인 요청(대용량 업로드/다운로드 포함)이
+// 끝나기를 제한 시간까지 기다린 뒤, 남은 연결은 강제로 닫는다.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// server 관리 대상 서버 하나
+type server struct {
+	name     string
+	http     *http.Server
+	listener net.Listener
+}
+
+// Manager 서버 묶음의 시작과 종료 관리
+type Manager struct {
+	shutdownTimeout time.Duration
+	servers         []*server
+}
+
+// New shutdownTimeout은 종료 시 진행 중인 요청을 기다리는 최대 시간
+func New(shutdownTimeout time.Duration) *Manager {
+	return &Manager{shutdownTimeout: shutdownTimeout}
+}
+
+// Add 서버 등록 (Run 호출 전에 등록해야 함)
+func (m *Manager) Add(name string, srv *http.Server, listener net.Listener) {
+	m.servers = append(m.servers, &server{name: name, http: srv, listener: listener})
+}
+
+// Run 등록된 서버를 모두 시작하고 ctx가 취소되거나 서버 하나가 실패할 때까지 대기한 뒤 모두 종료
+//
+// ctx 취소로 정상 종료되면 nil, 서버가 실패했거나 제한 시간 안에 종료하지 못하면 에러를 반환한다.
+func (m *Manager) Run(ctx context.Context) error {
+	errs := make(chan error, len(m.servers))
+	for _, s := range m.servers {
+		go func(s *server) {
+			slog.Info("서버 시작", "server", s.name, "addr", s.listener.Addr().String())
+			if err := s.http.Serve(s.listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				errs <- fmt.Errorf("%s 서버 실행 실패: %w", s.name, err)
+				return
+			}
+			errs <- nil
+		}(s)
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		slog.Info("종료 신호 수신, 진행 중인 요청 완료 대기", "timeout", m.shutdownTimeout)
+	case runErr = <-errs:
+		if runErr == nil {
+			runErr = errors.New("서버가 예기치 않게 종료되었습니다")
+		}
+		slog.Error("서버 실패, 나머지 서버 종료", "error", runErr)
+	}
+
+	if err := m.Shutdown(); err != nil {
+		return errors.Join(runErr, err)
+	}
+	return runErr
+}
+
+// Shutdown 모든 서버에서 새 연결을 받지 않고, 진행 중인 요청을 제한 시간까지 기다린 뒤 남은 연결을 닫음
+func (m *Manager) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(m.servers))
+	for i, s := range m.servers {
+		wg.Add(1)
+		go func(i int, s *server) {
+			defer wg.Done()
+			if err := s.http.Shutdown(ctx); err != nil {
+				// 제한 시간 초과: 남은 업로드/다운로드 연결을 강제로 끊음
+				s.http.Close()
+				errs[i] = fmt.Errorf("%s 서버 종료 제한 시간 초과: %w", s.name, err)
+				return
+			}
+			slog.Info("서버 종료", "server", s.name)
+		}(i, s)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
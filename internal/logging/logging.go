@@ -0,0 +1,79 @@
+// Package logging log/slog 기반 구조화 로그 설정과 요청 ID 전파
+//
+// 컨텍스트에 추가한 속성(요청 ID, 작업 ID 등)은 slog.InfoContext 같은
+// *Context 함수로 기록할 때 자동으로 포함된다.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"file-board/internal/config"
+)
+
+type attrsKey struct{}
+type requestIDKey struct{}
+
+// Setup 설정된 형식(json/text)과 수준으로 기본 로거 설정 (표준 log 패키지 출력도 slog로 전달)
+func Setup(cfg config.LogConfig, w io.Writer) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, fmt.Errorf("잘못된 로그 수준: %s", cfg.Level)
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch strings.ToLower(cfg.Format) {
+	case "json":
+		handler = slog.NewJSONHandler(w, options)
+	case "text", "":
+		handler = slog.NewTextHandler(w, options)
+	default:
+		return nil, fmt.Errorf("잘못된 로그 형식: %s (json 또는 text)", cfg.Format)
+	}
+
+	logger := slog.New(contextHandler{handler})
+	slog.SetDefault(logger)
+	return logger, nil
+}
+
+// With 이후 로그에 포함할 속성을 컨텍스트에 추가
+func With(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	merged := append(append([]slog.Attr(nil), existing...), attrs...)
+	return context.WithValue(ctx, attrsKey{}, merged)
+}
+
+// WithRequestID 요청 ID를 컨텍스트에 저장하고 로그 속성으로 추가
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return With(context.WithValue(ctx, requestIDKey{}, requestID), slog.String("request_id", requestID))
+}
+
+// RequestID 컨텍스트의 요청 ID (없으면 빈 문자열)
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// contextHandler 컨텍스트에 저장된 속성을 로그 레코드에 추가
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs, ok := ctx.Value(attrsKey{}).([]slog.Attr); ok {
+		record.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,112 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"file-board/internal/config"
+)
+
+// setupJSON JSON 로거를 설정하고 테스트 후 기존 기본 로거 복원
+func setupJSON(t *testing.T, level string) (*slog.Logger, *bytes.Buffer) {
+	t.Helper()
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	var buf bytes.Buffer
+	logger, err := Setup(config.LogConfig{Format: "json", Level: level}, &buf)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	return logger, &buf
+}
+
+// records 줄 단위 JSON 로그를 해석
+func records(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var out []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("unmarshal %q: %v", line, err)
+		}
+		out = append(out, record)
+	}
+	return out
+}
+
+func TestContextAttributes(t *testing.T) {
+	logger, buf := setupJSON(t, "info")
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	child := With(ctx, slog.Int64("job_id", 7))
+	With(ctx, slog.String("sibling", "x")) // 다른 컨텍스트에 추가한 속성은 섞이지 않음
+
+	slog.InfoContext(child, "with context", "key", "value")
+	logger.WithGroup("grp").With("static", 1).InfoContext(ctx, "grouped")
+	slog.Info("no context")
+	slog.DebugContext(child, "below level")
+
+	got := records(t, buf)
+	if len(got) != 3 {
+		t.Fatalf("got %d records, want 3: %s", len(got), buf.String())
+	}
+
+	first := got[0]
+	if first["request_id"] != "req-1" || first["job_id"] != float64(7) || first["key"] != "value" {
+		t.Errorf("record = %v, want request_id, job_id and key", first)
+	}
+	if _, ok := first["sibling"]; ok {
+		t.Errorf("record = %v, attribute from sibling context leaked", first)
+	}
+
+	// WithGroup/With로 만든 로거도 컨텍스트 속성을 추가 (그룹 안에 기록됨)
+	group, ok := got[1]["grp"].(map[string]interface{})
+	if !ok || group["request_id"] != "req-1" || group["static"] != float64(1) {
+		t.Errorf("grouped record = %v", got[1])
+	}
+
+	if _, ok := got[2]["request_id"]; ok {
+		t.Errorf("record without context = %v, want no request_id", got[2])
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	if id := RequestID(context.Background()); id != "" {
+		t.Errorf("RequestID(empty) = %q", id)
+	}
+	if id := RequestID(WithRequestID(context.Background(), "abc")); id != "abc" {
+		t.Errorf("RequestID = %q, want abc", id)
+	}
+}
+
+func TestSetupErrors(t *testing.T) {
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	for _, cfg := range []config.LogConfig{
+		{Format: "json", Level: "verbose"},
+		{Format: "xml", Level: "info"},
+	} {
+		if _, err := Setup(cfg, &bytes.Buffer{}); err == nil {
+			t.Errorf("Setup(%+v) error = nil, want error", cfg)
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := Setup(config.LogConfig{Format: "TEXT", Level: "warn"}, &buf); err != nil {
+		t.Fatalf("Setup(text): %v", err)
+	}
+	slog.Info("hidden")
+	slog.WarnContext(WithRequestID(context.Background(), "r"), "shown")
+	if out := buf.String(); strings.Contains(out, "hidden") || !strings.Contains(out, "msg=shown request_id=r") {
+		t.Errorf("text output = %q", out)
+	}
+}
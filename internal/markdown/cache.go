@@ -0,0 +1,74 @@
+package markdown
+
+import (
+	"container/list"
+	"hash/fnv"
+	"html/template"
+	"sync"
+)
+
+// Cache 게시글 ID별 렌더링 결과 캐시 (LRU, 내용이 바뀌면 다시 렌더링)
+type Cache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[int]*list.Element
+	order    *list.List // 앞쪽이 최근 사용
+}
+
+type cacheEntry struct {
+	key  int
+	hash uint64
+	html template.HTML
+}
+
+// NewCache 최대 capacity개 게시글의 렌더링 결과를 보관하는 캐시 생성
+func NewCache(capacity int) *Cache {
+	return &Cache{
+		capacity: capacity,
+		entries:  make(map[int]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Render 캐시된 결과가 있고 내용이 같으면 재사용, 아니면 렌더링 후 저장
+func (c *Cache) Render(key int, source string) template.HTML {
+	hash := contentHash(source)
+
+	c.mu.Lock()
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		if entry.hash == hash {
+			c.order.MoveToFront(elem)
+			c.mu.Unlock()
+			return entry.html
+		}
+	}
+	c.mu.Unlock()
+
+	// 렌더링은 잠금 밖에서 수행
+	html := Render(source)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = &cacheEntry{key: key, hash: hash, html: html}
+		c.order.MoveToFront(elem)
+		return html
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, hash: hash, html: html})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+	return html
+}
+
+// contentHash 내용 변경 감지용 해시
+func contentHash(source string) uint64 {
+	hasher := fnv.New64a()
+	hasher.Write([]byte(source))
+	return hasher.Sum64()
+}
@@ -0,0 +1,42 @@
+// Package markdown 마크다운을 HTML로 변환하고 허용 목록 기반으로 정리(sanitize)
+package markdown
+
+import (
+	"bytes"
+	"html/template"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	// GFM 확장(표, 취소선, 자동 링크, 체크리스트) 사용, 원본 HTML은 렌더링하지 않음
+	converter = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	policy = newPolicy()
+)
+
+// newPolicy 사용자 콘텐츠용 허용 목록
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	// 코드 블록 언어 표시 클래스만 허용
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	// 체크리스트 항목
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// Render 마크다운을 안전한 HTML로 변환
+func Render(source string) template.HTML {
+	var buf bytes.Buffer
+	if err := converter.Convert([]byte(source), &buf); err != nil {
+		// 변환에 실패하면 원문을 이스케이프하여 그대로 표시
+		return template.HTML("<pre>" + template.HTMLEscapeString(source) + "</pre>")
+	}
+	return template.HTML(policy.SanitizeBytes(buf.Bytes()))
+}
@@ -0,0 +1,113 @@
+package metrics
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+)
+
+// Metrics 게시판 서버 지표 (nil이면 기록하지 않음)
+type Metrics struct {
+	Registry *Registry
+
+	requests        *CounterVec
+	requestDuration *HistogramVec
+	uploadBytes     *CounterVec
+	uploadedFiles   *CounterVec
+	downloadBytes   *CounterVec
+}
+
+// 업로드 결과 레이블
+const (
+	uploadStored       = "stored"       // 새 파일로 저장
+	uploadDeduplicated = "deduplicated" // 같은 내용의 파일이 있어 재사용
+)
+
+// New 요청/업로드/다운로드 지표 등록
+func New() *Metrics {
+	r := NewRegistry()
+	m := &Metrics{
+		Registry: r,
+		requests: r.NewCounter("http_requests_total",
+			"처리한 HTTP 요청 수", "server", "method", "route", "status"),
+		requestDuration: r.NewHistogram("http_request_duration_seconds",
+			"HTTP 요청 처리 시간", DefaultBuckets, "server", "method", "route"),
+		uploadBytes: r.NewCounter("board_upload_bytes_total",
+			"업로드된 파일 크기 합계 (deduplicated는 저장하지 않고 기존 파일을 재사용한 크기)", "result"),
+		uploadedFiles: r.NewCounter("board_uploaded_files_total",
+			"업로드된 파일 수", "result"),
+		downloadBytes: r.NewCounter("board_download_bytes_total",
+			"다운로드 응답으로 보낸 바이트 수", "server", "route"),
+	}
+
+	r.NewGaugeFunc("board_upload_dedup_ratio",
+		"서버 시작 후 업로드된 파일 중 중복 제거된 비율", nil, func() []Sample {
+			deduplicated := m.uploadedFiles.Value(uploadDeduplicated)
+			total := deduplicated + m.uploadedFiles.Value(uploadStored)
+			if total == 0 {
+				return Value(0)
+			}
+			return Value(deduplicated / total)
+		})
+
+	return m
+}
+
+// ObserveRequest HTTP 요청 하나 기록 (route는 등록된 경로 패턴)
+func (m *Metrics) ObserveRequest(server, method, route string, status int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.requests.Inc(server, method, route, strconv.Itoa(status))
+	m.requestDuration.Observe(duration.Seconds(), server, method, route)
+}
+
+// AddDownloadBytes 다운로드 응답 크기 기록
+func (m *Metrics) AddDownloadBytes(server, route string, bytes int) {
+	if m == nil || bytes <= 0 {
+		return
+	}
+	m.downloadBytes.Add(float64(bytes), server, route)
+}
+
+// ObserveUpload 업로드된 파일 하나 기록
+func (m *Metrics) ObserveUpload(size int64, deduplicated bool) {
+	if m == nil {
+		return
+	}
+	result := uploadStored
+	if deduplicated {
+		result = uploadDeduplicated
+	}
+	m.uploadBytes.Add(float64(size), result)
+	m.uploadedFiles.Inc(result)
+}
+
+// RegisterDBStats 데이터베이스 연결 풀 상태(sql.DB.Stats) 등록
+func (m *Metrics) RegisterDBStats(db *sql.DB) {
+	gauge := func(name, help string, value func(sql.DBStats) float64) {
+		m.Registry.NewGaugeFunc(name, help, nil, func() []Sample { return Value(value(db.Stats())) })
+	}
+	counter := func(name, help string, value func(sql.DBStats) float64) {
+		m.Registry.NewCounterFunc(name, help, nil, func() []Sample { return Value(value(db.Stats())) })
+	}
+
+	gauge("db_max_open_connections", "최대 연결 수 설정 (0이면 제한 없음)",
+		func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) })
+	gauge("db_open_connections", "열려 있는 연결 수",
+		func(s sql.DBStats) float64 { return float64(s.OpenConnections) })
+	gauge("db_in_use_connections", "사용 중인 연결 수",
+		func(s sql.DBStats) float64 { return float64(s.InUse) })
+	gauge("db_idle_connections", "유휴 연결 수",
+		func(s sql.DBStats) float64 { return float64(s.Idle) })
+	counter("db_wait_count_total", "연결을 기다린 횟수",
+		func(s sql.DBStats) float64 { return float64(s.WaitCount) })
+	counter("db_wait_duration_seconds_total", "연결을 기다린 시간 합계",
+		func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() })
+	counter("db_max_idle_closed_total", "유휴 연결 수 제한으로 닫은 연결 수",
+		func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) })
+	counter("db_max_idle_time_closed_total", "유휴 시간 제한으로 닫은 연결 수",
+		func(s sql.DBStats) float64 { return float64(s.MaxIdleTimeClosed) })
+	counter("db_max_lifetime_closed_total", "최대 수명 제한으로 닫은 연결 수",
+		func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) })
+}
@@ -0,0 +1,279 @@
+// Package metrics Prometheus 텍스트 형식(version 0.0.4)으로 내보내는 간단한 지표 모음
+//
+// 카운터와 히스토그램은 레이블 값 조합별로 메모리에 누적하고,
+// 게이지처럼 조회 시점에 계산하는 값은 함수로 등록해 수집할 때마다 호출한다.
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets 요청 처리 시간 히스토그램 기본 구간 (초, 대용량 다운로드를 고려해 긴 구간 포함)
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300}
+
+// Sample 함수형 지표가 반환하는 값 하나 (Labels는 등록한 레이블 이름 순서)
+type Sample struct {
+	Labels []string
+	Value  float64
+}
+
+// family 같은 이름을 가진 지표 묶음
+type family interface {
+	write(w *bufio.Writer)
+}
+
+// Registry 지표 등록 및 출력 (등록 순서대로 출력)
+type Registry struct {
+	mu       sync.Mutex
+	families []family
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families = append(r.families, f)
+}
+
+// Write 등록된 모든 지표를 텍스트 형식으로 기록
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.Lock()
+	families := append([]family(nil), r.families...)
+	r.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(bw)
+	}
+	return bw.Flush()
+}
+
+// Handler 지표 조회 HTTP 핸들러
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		r.Write(w)
+	})
+}
+
+// CounterVec 레이블별 누적 카운터
+type CounterVec struct {
+	name, help string
+	labelNames []string
+
+	mu     sync.Mutex
+	values map[string]*counterValue
+}
+
+type counterValue struct {
+	labels []string
+	value  float64
+}
+
+// NewCounter 카운터 등록 (이름은 관례대로 _total로 끝나야 함)
+func (r *Registry) NewCounter(name, help string, labelNames ...string) *CounterVec {
+	c := &CounterVec{name: name, help: help, labelNames: labelNames, values: make(map[string]*counterValue)}
+	r.register(c)
+	return c
+}
+
+// Add 레이블 값 조합의 카운터 증가 (음수는 무시)
+func (c *CounterVec) Add(delta float64, labels ...string) {
+	if delta < 0 {
+		return
+	}
+	key := labelKey(labels)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	v, ok := c.values[key]
+	if !ok {
+		v = &counterValue{labels: append([]string(nil), labels...)}
+		c.values[key] = v
+	}
+	v.value += delta
+}
+
+// Inc 카운터 1 증가
+func (c *CounterVec) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+// Value 레이블 값 조합의 현재 값
+func (c *CounterVec) Value(labels ...string) float64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if v, ok := c.values[labelKey(labels)]; ok {
+		return v.value
+	}
+	return 0
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	writeHeader(w, c.name, c.help, "counter")
+	for _, key := range sortedKeys(c.values) {
+		v := c.values[key]
+		writeSample(w, c.name, c.labelNames, v.labels, "", "", v.value)
+	}
+}
+
+// HistogramVec 레이블별 히스토그램
+type HistogramVec struct {
+	name, help string
+	labelNames []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	values map[string]*histogramValue
+}
+
+type histogramValue struct {
+	labels []string
+	counts []uint64 // 구간별 누적이 아닌 개별 개수 (출력 시 누적)
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram 히스토그램 등록 (buckets는 오름차순)
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	h := &HistogramVec{name: name, help: help, labelNames: labelNames, buckets: buckets, values: make(map[string]*histogramValue)}
+	r.register(h)
+	return h
+}
+
+// Observe 관측값 기록
+func (h *HistogramVec) Observe(value float64, labels ...string) {
+	key := labelKey(labels)
+	index := sort.SearchFloat64s(h.buckets, value)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	v, ok := h.values[key]
+	if !ok {
+		v = &histogramValue{labels: append([]string(nil), labels...), counts: make([]uint64, len(h.buckets))}
+		h.values[key] = v
+	}
+	if index < len(h.buckets) {
+		v.counts[index]++
+	}
+	v.count++
+	v.sum += value
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	writeHeader(w, h.name, h.help, "histogram")
+	for _, key := range sortedKeys(h.values) {
+		v := h.values[key]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += v.counts[i]
+			writeSample(w, h.name+"_bucket", h.labelNames, v.labels, "le", formatFloat(bound), float64(cumulative))
+		}
+		writeSample(w, h.name+"_bucket", h.labelNames, v.labels, "le", "+Inf", float64(v.count))
+		writeSample(w, h.name+"_sum", h.labelNames, v.labels, "", "", v.sum)
+		writeSample(w, h.name+"_count", h.labelNames, v.labels, "", "", float64(v.count))
+	}
+}
+
+// funcFamily 수집할 때마다 함수를 호출해 값을 얻는 지표
+type funcFamily struct {
+	name, help, kind string
+	labelNames       []string
+	collect          func() []Sample
+}
+
+// NewGaugeFunc 조회 시점에 계산하는 게이지 등록
+func (r *Registry) NewGaugeFunc(name, help string, labelNames []string, collect func() []Sample) {
+	r.register(&funcFamily{name: name, help: help, kind: "gauge", labelNames: labelNames, collect: collect})
+}
+
+// NewCounterFunc 외부에서 누적하는 값(예: sql.DBStats)을 카운터로 등록
+func (r *Registry) NewCounterFunc(name, help string, labelNames []string, collect func() []Sample) {
+	r.register(&funcFamily{name: name, help: help, kind: "counter", labelNames: labelNames, collect: collect})
+}
+
+func (f *funcFamily) write(w *bufio.Writer) {
+	writeHeader(w, f.name, f.help, f.kind)
+	for _, s := range f.collect() {
+		writeSample(w, f.name, f.labelNames, s.Labels, "", "", s.Value)
+	}
+}
+
+// Value 레이블 없는 함수형 지표 값 하나
+func Value(v float64) []Sample {
+	return []Sample{{Value: v}}
+}
+
+func writeHeader(w *bufio.Writer, name, help, kind string) {
+	w.WriteString("# HELP " + name + " " + strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(help) + "\n")
+	w.WriteString("# TYPE " + name + " " + kind + "\n")
+}
+
+// writeSample 값 한 줄 기록 (extraName이 있으면 히스토그램 le 같은 추가 레이블)
+func writeSample(w *bufio.Writer, name string, labelNames, labels []string, extraName, extraValue string, value float64) {
+	w.WriteString(name)
+
+	var pairs []string
+	for i, labelName := range labelNames {
+		labelValue := ""
+		if i < len(labels) {
+			labelValue = labels[i]
+		}
+		pairs = append(pairs, labelName+`="`+escapeLabel(labelValue)+`"`)
+	}
+	if extraName != "" {
+		pairs = append(pairs, extraName+`="`+extraValue+`"`)
+	}
+	if len(pairs) > 0 {
+		w.WriteString("{" + strings.Join(pairs, ",") + "}")
+	}
+
+	w.WriteString(" " + formatFloat(value) + "\n")
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// labelKey 레이블 값 조합을 맵 키로 변환 (레이블 값에 나올 수 없는 구분자 사용)
+func labelKey(labels []string) string {
+	return strings.Join(labels, "\xff")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
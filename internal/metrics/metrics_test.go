@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"bytes"
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func render(t *testing.T, r *Registry) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := r.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	return buf.String()
+}
+
+func TestCounterExposition(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("test_requests_total", "요청 수\n줄바꿈과 역슬래시 \\ 포함", "route", "status")
+	c.Inc("/b", "200")
+	c.Add(2.5, "/a", "500")
+	c.Add(-1, "/a", "500") // 음수는 무시
+	c.Inc(`/path "quoted" \ back`+"\nslash", "200")
+
+	want := `# HELP test_requests_total 요청 수\n줄바꿈과 역슬래시 \\ 포함
+# TYPE test_requests_total counter
+test_requests_total{route="/a",status="500"} 2.5
+test_requests_total{route="/b",status="200"} 1
+test_requests_total{route="/path \"quoted\" \\ back\nslash",status="200"} 1
+`
+	if got := render(t, r); got != want {
+		t.Errorf("exposition =\n%s\nwant\n%s", got, want)
+	}
+	if v := c.Value("/a", "500"); v != 2.5 {
+		t.Errorf("Value = %v, want 2.5", v)
+	}
+}
+
+func TestHistogramExposition(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogram("test_duration_seconds", "처리 시간", []float64{0.1, 1, 10}, "route")
+	h.Observe(0.05, "/a")
+	h.Observe(0.1, "/a") // 경계값은 해당 구간에 포함 (le)
+	h.Observe(5, "/a")
+	h.Observe(100, "/a") // 모든 구간보다 크면 +Inf에만 포함
+
+	want := `# HELP test_duration_seconds 처리 시간
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{route="/a",le="0.1"} 2
+test_duration_seconds_bucket{route="/a",le="1"} 2
+test_duration_seconds_bucket{route="/a",le="10"} 3
+test_duration_seconds_bucket{route="/a",le="+Inf"} 4
+test_duration_seconds_sum{route="/a"} 105.15
+test_duration_seconds_count{route="/a"} 4
+`
+	if got := render(t, r); got != want {
+		t.Errorf("exposition =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFuncExposition(t *testing.T) {
+	r := NewRegistry()
+	calls := 0
+	r.NewGaugeFunc("test_gauge", "게이지", nil, func() []Sample {
+		calls++
+		return Value(math.Inf(1))
+	})
+	r.NewCounterFunc("test_func_total", "카운터", []string{"kind"}, func() []Sample {
+		return []Sample{{Labels: []string{"a"}, Value: 1e21}, {Labels: nil, Value: math.NaN()}}
+	})
+	r.NewGaugeFunc("test_empty", "값 없음", nil, func() []Sample { return nil })
+
+	want := `# HELP test_gauge 게이지
+# TYPE test_gauge gauge
+test_gauge +Inf
+# HELP test_func_total 카운터
+# TYPE test_func_total counter
+test_func_total{kind="a"} 1e+21
+test_func_total{kind=""} NaN
+# HELP test_empty 값 없음
+# TYPE test_empty gauge
+`
+	if got := render(t, r); got != want {
+		t.Errorf("exposition =\n%s\nwant\n%s", got, want)
+	}
+	if calls != 1 {
+		t.Errorf("collect called %d times, want 1", calls)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := NewRegistry()
+	r.NewCounter("test_total", "테스트").Inc()
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != "text/plain; version=0.0.4; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(rec.Body.String(), "\ntest_total 1\n") {
+		t.Errorf("body = %q", rec.Body.String())
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	var nilMetrics *Metrics
+	nilMetrics.ObserveRequest("user", "GET", "/", 200, time.Second)
+	nilMetrics.ObserveUpload(10, false)
+	nilMetrics.AddDownloadBytes("user", "/download/:id", 10)
+
+	m := New()
+	m.ObserveRequest("user", "GET", "/posts/:id", 404, 20*time.Millisecond)
+	m.ObserveUpload(100, false)
+	m.ObserveUpload(50, true)
+	m.ObserveUpload(50, true)
+	m.AddDownloadBytes("admin", "/download/:id", 0) // 0바이트는 기록하지 않음
+
+	out := render(t, m.Registry)
+	for _, line := range []string{
+		`http_requests_total{server="user",method="GET",route="/posts/:id",status="404"} 1`,
+		`http_request_duration_seconds_bucket{server="user",method="GET",route="/posts/:id",le="0.025"} 1`,
+		`http_request_duration_seconds_bucket{server="user",method="GET",route="/posts/:id",le="0.01"} 0`,
+		`board_upload_bytes_total{result="deduplicated"} 100`,
+		`board_uploaded_files_total{result="stored"} 1`,
+		`board_upload_dedup_ratio 0.6666666666666666`,
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Errorf("exposition missing %q", line)
+		}
+	}
+	if strings.Contains(out, "board_download_bytes_total{") {
+		t.Error("zero-byte download recorded")
+	}
+}
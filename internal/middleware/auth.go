@@ -16,7 +16,7 @@ const (
 func RequireAdminAuth() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
 		session := sessions.Default(c)
-		
+
 		// 세션에서 인증 상태 확인
 		if auth := session.Get(AdminSessionKey); auth == nil {
 			// 인증되지 않은 경우 로그인 페이지로 리다이렉트
@@ -24,16 +24,17 @@ func RequireAdminAuth() gin.HandlerFunc {
 			c.Abort()
 			return
 		}
-		
+
 		// 세션 만료 시간 갱신
 		session.Set(AdminSessionKey, true)
 		session.Options(sessions.Options{
 			MaxAge:   SessionMaxAge,
 			HttpOnly: true,
-			Secure:   false, // 개발환경에서는 false, 프로덕션에서는 true
+			SameSite: http.SameSiteStrictMode, // 다른 사이트에서 시작한 요청에는 세션 쿠키를 보내지 않음
+			Secure:   false,                   // 개발환경에서는 false, 프로덕션에서는 true
 		})
 		session.Save()
-		
+
 		c.Next()
 	})
 }
@@ -48,39 +49,40 @@ func HandleAdminLogin(adminPassword string) gin.HandlerFunc {
 				c.Redirect(http.StatusFound, "/")
 				return
 			}
-			
+
 			// 로그인 페이지 표시
 			c.HTML(http.StatusOK, "admin_login.html", gin.H{})
 			return
 		}
-		
+
 		if c.Request.Method == "POST" {
 			password := c.PostForm("password")
-			
+
 			if password == "" {
 				c.HTML(http.StatusBadRequest, "admin_login.html", gin.H{
 					"error": "비밀번호를 입력해주세요.",
 				})
 				return
 			}
-			
+
 			if password != adminPassword {
 				c.HTML(http.StatusUnauthorized, "admin_login.html", gin.H{
 					"error": "비밀번호가 올바르지 않습니다.",
 				})
 				return
 			}
-			
+
 			// 로그인 성공 - 세션 설정
 			session := sessions.Default(c)
 			session.Set(AdminSessionKey, true)
 			session.Options(sessions.Options{
 				MaxAge:   SessionMaxAge,
 				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
 				Secure:   false, // 개발환경에서는 false, 프로덕션에서는 true
 			})
 			session.Save()
-			
+
 			// 관리자 페이지로 리다이렉트
 			c.Redirect(http.StatusFound, "/")
 			return
@@ -95,16 +97,17 @@ func HandleAdminLogout() gin.HandlerFunc {
 		c.Header("Cache-Control", "no-cache, no-store, must-revalidate")
 		c.Header("Pragma", "no-cache")
 		c.Header("Expires", "0")
-		
+
 		session := sessions.Default(c)
 		session.Clear()
 		session.Options(sessions.Options{
 			MaxAge:   -1, // 즉시 만료
 			HttpOnly: true,
+			SameSite: http.SameSiteStrictMode,
 			Secure:   false,
 		})
 		session.Save()
-		
+
 		c.Redirect(http.StatusFound, "/login")
 	})
 }
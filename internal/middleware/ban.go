@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"file-board/internal/logging"
+	"file-board/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RejectBannedIP 차단된 IP의 요청을 거부하는 미들웨어 (업로드 라우트에 적용)
+func RejectBannedIP(banService *services.BanService) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		ban, err := banService.FindActiveBan(c.ClientIP())
+		if err != nil {
+			// 차단 목록 조회 실패 시 서비스 중단을 막기 위해 요청은 허용
+			slog.ErrorContext(c.Request.Context(), "차단 목록 확인 실패", "error", err)
+			c.Next()
+			return
+		}
+
+		if ban != nil {
+			message := "차단된 IP에서는 게시글을 작성할 수 없습니다."
+			if ban.Reason != "" {
+				message += " (사유: " + ban.Reason + ")"
+			}
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": message, "request_id": logging.RequestID(c.Request.Context())})
+			return
+		}
+
+		c.Next()
+	})
+}
@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TrustedProxies 프록시 헤더를 신뢰할 네트워크 목록
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies IP 또는 CIDR 문자열 목록을 파싱
+func ParseTrustedProxies(entries []string) (TrustedProxies, error) {
+	var nets TrustedProxies
+	for _, entry := range entries {
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("잘못된 프록시 주소: %s", entry)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 프록시 CIDR: %s", entry)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// Contains 주어진 IP가 신뢰할 프록시 대역에 속하는지 확인
+func (t TrustedProxies) Contains(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range t {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP 신뢰할 프록시를 거친 요청의 실제 클라이언트 IP 복원 미들웨어
+//
+// 직접 연결한 상대가 신뢰할 프록시인 경우에만 Forwarded(RFC 7239),
+// X-Forwarded-For, X-Real-IP 순서로 헤더를 확인하고, 결과를 RemoteAddr에
+// 반영하여 이후 c.ClientIP()가 복원된 주소를 반환하도록 한다.
+// 엔진에는 SetTrustedProxies(nil)을 설정해 gin 자체의 헤더 처리를 끈다.
+func ClientIP(trusted TrustedProxies) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		host, port, err := net.SplitHostPort(strings.TrimSpace(c.Request.RemoteAddr))
+		if err != nil || !trusted.Contains(net.ParseIP(host)) {
+			c.Next()
+			return
+		}
+
+		if ip := resolveForwardedIP(c, trusted); ip != nil {
+			c.Request.RemoteAddr = net.JoinHostPort(ip.String(), port)
+		}
+
+		c.Next()
+	})
+}
+
+// resolveForwardedIP 프록시 헤더에서 클라이언트 IP 추출
+func resolveForwardedIP(c *gin.Context, trusted TrustedProxies) net.IP {
+	// 프록시 체인: 왼쪽이 원래 클라이언트, 오른쪽이 가장 가까운 프록시
+	var chain []net.IP
+	if forwarded := c.Request.Header.Values("Forwarded"); len(forwarded) > 0 {
+		chain = parseForwarded(forwarded)
+	} else if xff := c.Request.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+		chain = parseForwardedFor(xff)
+	}
+
+	if len(chain) > 0 {
+		// 오른쪽부터 신뢰할 프록시를 건너뛰고 첫 번째 외부 주소를 클라이언트로 간주
+		for i := len(chain) - 1; i >= 0; i-- {
+			if chain[i] == nil {
+				// 파싱할 수 없는 항목(obfuscated identifier 등) 이후는 신뢰하지 않음
+				return nil
+			}
+			if i == 0 || !trusted.Contains(chain[i]) {
+				return chain[i]
+			}
+		}
+	}
+
+	if realIP := strings.TrimSpace(c.GetHeader("X-Real-IP")); realIP != "" {
+		return net.ParseIP(realIP)
+	}
+
+	return nil
+}
+
+// parseForwardedFor X-Forwarded-For 헤더 값들을 IP 목록으로 변환
+func parseForwardedFor(values []string) []net.IP {
+	var chain []net.IP
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			chain = append(chain, parseNodeIP(strings.TrimSpace(item)))
+		}
+	}
+	return chain
+}
+
+// parseForwarded RFC 7239 Forwarded 헤더의 for= 값들을 IP 목록으로 변환
+func parseForwarded(values []string) []net.IP {
+	var chain []net.IP
+	for _, value := range values {
+		for _, element := range strings.Split(value, ",") {
+			for _, pair := range strings.Split(element, ";") {
+				key, val, found := strings.Cut(strings.TrimSpace(pair), "=")
+				if !found || !strings.EqualFold(key, "for") {
+					continue
+				}
+				chain = append(chain, parseNodeIP(strings.Trim(val, `"`)))
+			}
+		}
+	}
+	return chain
+}
+
+// parseNodeIP "1.2.3.4", "1.2.3.4:80", "[2001:db8::1]:80" 형태의 노드 값을 IP로 변환
+func parseNodeIP(node string) net.IP {
+	if ip := net.ParseIP(node); ip != nil {
+		return ip
+	}
+	if host, _, err := net.SplitHostPort(node); err == nil {
+		return net.ParseIP(host)
+	}
+	return net.ParseIP(strings.Trim(node, "[]"))
+}
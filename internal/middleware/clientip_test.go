@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		match   []string
+		noMatch []string
+		wantErr bool
+	}{
+		{name: "empty", entries: nil, noMatch: []string{"10.0.0.1", "::1"}},
+		{name: "single ipv4", entries: []string{"10.0.0.1"}, match: []string{"10.0.0.1"}, noMatch: []string{"10.0.0.2"}},
+		{name: "single ipv6", entries: []string{"2001:db8::1"}, match: []string{"2001:db8::1"}, noMatch: []string{"2001:db8::2"}},
+		{name: "cidr", entries: []string{"10.0.0.0/8", "fd00::/8"}, match: []string{"10.255.0.1", "fd12::1"}, noMatch: []string{"11.0.0.1", "fe80::1"}},
+		{name: "ipv4-mapped ipv6 peer", entries: []string{"10.0.0.0/8"}, match: []string{"::ffff:10.0.0.1"}},
+		{name: "invalid ip", entries: []string{"10.0.0"}, wantErr: true},
+		{name: "invalid cidr", entries: []string{"10.0.0.0/33"}, wantErr: true},
+		{name: "hostname", entries: []string{"proxy.local"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trusted, err := ParseTrustedProxies(tt.entries)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTrustedProxies(%q) error = nil, want error", tt.entries)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTrustedProxies(%q): %v", tt.entries, err)
+			}
+			for _, ip := range tt.match {
+				if !trusted.Contains(net.ParseIP(ip)) {
+					t.Errorf("Contains(%s) = false, want true", ip)
+				}
+			}
+			for _, ip := range tt.noMatch {
+				if trusted.Contains(net.ParseIP(ip)) {
+					t.Errorf("Contains(%s) = true, want false", ip)
+				}
+			}
+		})
+	}
+
+	var empty TrustedProxies
+	if empty.Contains(nil) {
+		t.Error("Contains(nil) = true, want false")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "2001:db8::/32"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		headers    map[string][]string
+		want       string
+	}{
+		{
+			name:       "untrusted peer without headers",
+			remoteAddr: "203.0.113.5:1234",
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "untrusted peer spoofing X-Forwarded-For",
+			remoteAddr: "203.0.113.5:1234",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.2.3.4"}},
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "untrusted peer spoofing Forwarded and X-Real-IP",
+			remoteAddr: "203.0.113.5:1234",
+			headers:    map[string][]string{"Forwarded": {"for=1.2.3.4"}, "X-Real-Ip": {"1.2.3.4"}},
+			want:       "203.0.113.5",
+		},
+		{
+			name:       "trusted peer without headers",
+			remoteAddr: "10.0.0.2:1234",
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "trusted peer with X-Forwarded-For",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.7"}},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "client-supplied XFF entry before the real client is ignored",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.2.3.4, 198.51.100.7, 10.0.0.3"}},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "multiple XFF header lines",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Forwarded-For": {"1.2.3.4", "198.51.100.7"}},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "all hops trusted uses leftmost",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Forwarded-For": {"10.1.1.1, 10.0.0.3"}},
+			want:       "10.1.1.1",
+		},
+		{
+			name:       "unparsable hop stops resolution",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.7, garbage"}},
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "Forwarded takes precedence over X-Forwarded-For",
+			remoteAddr: "10.0.0.2:1234",
+			headers: map[string][]string{
+				"Forwarded":       {`for=198.51.100.7;proto=https`},
+				"X-Forwarded-For": {"1.2.3.4"},
+			},
+			want: "198.51.100.7",
+		},
+		{
+			name:       "Forwarded with quoted ipv6 and port",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"Forwarded": {`for="[2001:db9::1]:4711", for=10.0.0.3`}},
+			want:       "2001:db9::1",
+		},
+		{
+			name:       "Forwarded obfuscated identifier",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"Forwarded": {"for=_hidden"}},
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "X-Real-IP from trusted peer",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Real-Ip": {" 198.51.100.7 "}},
+			want:       "198.51.100.7",
+		},
+		{
+			name:       "invalid X-Real-IP keeps peer address",
+			remoteAddr: "10.0.0.2:1234",
+			headers:    map[string][]string{"X-Real-Ip": {"not-an-ip"}},
+			want:       "10.0.0.2",
+		},
+		{
+			name:       "trusted ipv6 peer",
+			remoteAddr: "[2001:db8::10]:1234",
+			headers:    map[string][]string{"X-Forwarded-For": {"198.51.100.7"}},
+			want:       "198.51.100.7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			if err := r.SetTrustedProxies(nil); err != nil {
+				t.Fatal(err)
+			}
+			r.Use(ClientIP(trusted))
+			var got string
+			r.GET("/", func(c *gin.Context) { got = c.ClientIP() })
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for key, values := range tt.headers {
+				for _, value := range values {
+					req.Header.Add(key, value)
+				}
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
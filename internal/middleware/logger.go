@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"file-board/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 정상 응답이면 debug 수준으로만 기록하는 경로 (주기적으로 호출되는 상태 확인)
+var quietRoutes = map[string]bool{"/healthz": true, "/readyz": true}
+
+// RequestLogger 요청마다 한 줄씩 구조화 로그 기록 (gin 기본 로거 대체, 5xx는 error, 4xx는 warn)
+func RequestLogger(server string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		case quietRoutes[c.FullPath()]:
+			level = slog.LevelDebug
+		}
+
+		attrs := []slog.Attr{
+			slog.String("server", server),
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+			slog.Int("bytes", c.Writer.Size()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", c.Errors.String()))
+		}
+		slog.LogAttrs(c.Request.Context(), level, "요청 처리", attrs...)
+	})
+}
+
+// Recovery 핸들러 panic을 로그로 남기고 요청 ID가 포함된 500 응답 반환
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(nil, func(c *gin.Context, recovered any) {
+		ctx := c.Request.Context()
+		slog.ErrorContext(ctx, "요청 처리 중 panic", "panic", recovered, "stack", string(debug.Stack()))
+		if c.Writer.Written() {
+			c.Abort()
+			return
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error":      "서버 내부 오류가 발생했습니다.",
+			"request_id": logging.RequestID(ctx),
+		})
+	})
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+	"time"
+
+	"file-board/internal/metrics"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 응답 크기를 다운로드 바이트로 집계할 경로 패턴
+var downloadRoutePrefixes = []string{"/download/", "/raw/", "/archive", "/export"}
+
+// RequestMetrics 라우트별 요청 수, 처리 시간, 다운로드 바이트 기록 (server는 user/admin 구분)
+func RequestMetrics(m *metrics.Metrics, server string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		// 등록된 경로 패턴 기준으로 집계 (실제 URL을 쓰면 레이블 종류가 무한히 늘어남)
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		m.ObserveRequest(server, c.Request.Method, route, c.Writer.Status(), time.Since(start))
+
+		for _, prefix := range downloadRoutePrefixes {
+			if strings.HasPrefix(route, prefix) {
+				m.AddDownloadBytes(server, route, c.Writer.Size())
+				break
+			}
+		}
+	})
+}
+
+// RequireMetricsToken Authorization: Bearer 토큰이 일치할 때만 지표 조회 허용
+func RequireMetricsToken(token string) gin.HandlerFunc {
+	expected := []byte("Bearer " + token)
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader("Authorization")), expected) != 1 {
+			c.Header("WWW-Authenticate", `Bearer realm="metrics"`)
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+		c.Next()
+	})
+}
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"net/http"
+
+	"file-board/internal/logging"
+	"file-board/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireAnonymousPosting 관리자가 익명 게시를 끈 동안 게시글/댓글 작성 요청을 거부하는 미들웨어
+func RequireAnonymousPosting(settings *services.SettingsService) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if !settings.Current().AnonymousPosting {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "현재 게시글과 댓글 작성이 중지되어 있습니다.", "request_id": logging.RequestID(c.Request.Context())})
+			return
+		}
+		c.Next()
+	})
+}
@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+
+	"file-board/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader 요청 ID를 주고받는 헤더
+const RequestIDHeader = "X-Request-ID"
+
+// 프록시가 넘겨준 요청 ID로 받아들일 형식 (로그 주입 방지)
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID 요청마다 ID를 부여해 요청 컨텍스트(로그)와 응답 헤더에 설정
+//
+// 프록시가 X-Request-ID를 보내면 형식이 올바른 경우 그대로 사용해 로그를 이어 볼 수 있게 한다.
+func RequestID() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = newRequestID()
+		}
+
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), requestID))
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	})
+}
+
+// newRequestID 무작위 16바이트 16진수 문자열
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"file-board/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	generated := regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool // 보낸 값을 그대로 사용해야 하는지
+	}{
+		{name: "uuid", header: "3f2b8c1e-9d4a-4e6b-8f0a-1c2d3e4f5a6b", keep: true},
+		{name: "dotted", header: "lb.abc_123", keep: true},
+		{name: "max length", header: strings.Repeat("a", 64), keep: true},
+		{name: "missing", header: ""},
+		{name: "too long", header: strings.Repeat("a", 65)},
+		{name: "newline injection", header: "abc\nlevel=ERROR msg=forged"},
+		{name: "space", header: "abc def"},
+		{name: "quote", header: `abc"def`},
+		{name: "equals", header: "a=b"},
+		{name: "non-ascii", header: "요청"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			r := gin.New()
+			r.Use(RequestID())
+			r.GET("/", func(c *gin.Context) {
+				seen = logging.RequestID(c.Request.Context())
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			r.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(RequestIDHeader)
+			if got != seen {
+				t.Errorf("response header %q != context request ID %q", got, seen)
+			}
+			if tt.keep {
+				if got != tt.header {
+					t.Errorf("request ID = %q, want %q", got, tt.header)
+				}
+				return
+			}
+			if !generated.MatchString(got) {
+				t.Errorf("request ID = %q, want newly generated ID", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ArchiveEntry 압축 파일 항목 구조체
+type ArchiveEntry struct {
+	ID             int          `json:"id"`
+	FileID         int          `json:"file_id"`
+	Index          int          `json:"index"`
+	Name           string       `json:"name"`
+	Size           int64        `json:"size"`
+	CompressedSize int64        `json:"compressed_size"`
+	ModifiedAt     sql.NullTime `json:"modified_at"`
+	IsDir          bool         `json:"is_dir"`
+}
+
+// SizeText 해제 후 크기를 읽기 쉬운 단위로 표시
+func (e *ArchiveEntry) SizeText() string {
+	switch {
+	case e.Size >= 1024*1024:
+		return fmt.Sprintf("%.2f MB", float64(e.Size)/(1024*1024))
+	case e.Size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(e.Size)/1024)
+	default:
+		return fmt.Sprintf("%d B", e.Size)
+	}
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// Board 게시판 정보
+type Board struct {
+	ID            int       `json:"id"`
+	Slug          string    `json:"slug"`
+	Name          string    `json:"name"`
+	Description   string    `json:"description"`
+	AllowFiles    bool      `json:"allow_files"`
+	AllowMessages bool      `json:"allow_messages"`
+	MaxFileSize   int64     `json:"max_file_size"` // 0이면 전체 설정 사용
+	Position      int       `json:"position"`
+	PostCount     int       `json:"post_count"` // 삭제되지 않은 게시글 수
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// AllowsPostType 게시판에 해당 종류의 게시글을 쓸 수 있는지 확인
+func (b *Board) AllowsPostType(postType string) bool {
+	switch postType {
+	case "file":
+		return b.AllowFiles
+	case "message":
+		return b.AllowMessages
+	}
+	return false
+}
+
+// EffectiveMaxFileSize 게시판 설정이 없으면 전체 최대 파일 크기 사용
+func (b *Board) EffectiveMaxFileSize(globalMax int64) int64 {
+	if b.MaxFileSize > 0 && b.MaxFileSize < globalMax {
+		return b.MaxFileSize
+	}
+	return globalMax
+}
+
+// MaxFileSizeMB 게시판 최대 파일 크기 (MB, 관리자 폼 표시용)
+func (b *Board) MaxFileSizeMB() int64 {
+	return b.MaxFileSize / (1024 * 1024)
+}
+
+// MaxFileSizeText 게시판에 적용되는 최대 파일 크기 표시
+func (b *Board) MaxFileSizeText(globalMax int64) string {
+	return strconv.FormatInt(b.EffectiveMaxFileSize(globalMax)/(1024*1024), 10) + "MB"
+}
+
+// BoardRequest 관리자 게시판 생성/수정 요청
+type BoardRequest struct {
+	Slug          string `json:"slug" binding:"required"`
+	Name          string `json:"name" binding:"required"`
+	Description   string `json:"description"`
+	AllowFiles    bool   `json:"allow_files"`
+	AllowMessages bool   `json:"allow_messages"`
+	MaxFileSizeMB int64  `json:"max_file_size_mb"` // 0이면 전체 설정 사용
+	Position      int    `json:"position"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Comment 게시글 댓글 (Replies에 답글이 트리 형태로 담김)
+type Comment struct {
+	ID        int           `json:"id"`
+	PostID    int           `json:"post_id"`
+	ParentID  sql.NullInt32 `json:"-"`
+	Content   string        `json:"content"`
+	IPAddress string        `json:"ip_address,omitempty"` // 관리자 조회 시에만 포함
+	CreatedAt time.Time     `json:"created_at"`
+	DeletedAt sql.NullTime  `json:"-"`
+	Deleted   bool          `json:"deleted"`
+	Replies   []*Comment    `json:"replies"`
+}
+
+// CommentRequest 댓글 작성 요청
+type CommentRequest struct {
+	Content  string `json:"content" binding:"required"`
+	ParentID int    `json:"parent_id"` // 0이면 최상위 댓글
+}
@@ -0,0 +1,26 @@
+package models
+
+// 상태 확인 결과
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// HealthCheck 준비 상태 확인 항목 하나의 결과
+type HealthCheck struct {
+	Name       string `json:"name"`
+	OK         bool   `json:"ok"`
+	Detail     string `json:"detail,omitempty"` // 확인한 값 또는 실패 사유
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// HealthReport 준비 상태 확인 결과 (Checks는 관리자에게만 노출)
+type HealthReport struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks,omitempty"`
+}
+
+// Ready 모든 항목이 정상인지 확인
+func (r *HealthReport) Ready() bool {
+	return r.Status == HealthStatusOK
+}
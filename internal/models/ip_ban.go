@@ -0,0 +1,28 @@
+package models
+
+import (
+	"database/sql"
+	"time"
+)
+
+// IPBan IP/CIDR 차단 정보
+type IPBan struct {
+	ID        int          `json:"id"`
+	CIDR      string       `json:"cidr"`
+	Reason    string       `json:"reason"`
+	ExpiresAt sql.NullTime `json:"expires_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+// IsActive 현재 유효한 차단인지 확인
+func (b *IPBan) IsActive() bool {
+	return !b.ExpiresAt.Valid || b.ExpiresAt.Time.After(time.Now())
+}
+
+// BanRequest 관리자 차단 요청
+type BanRequest struct {
+	CIDR          string `json:"cidr" binding:"required"`
+	Reason        string `json:"reason"`
+	DurationHours int    `json:"duration_hours"` // 0이면 영구 차단
+	DeletePosts   bool   `json:"delete_posts"`   // 해당 대역의 게시글 일괄 삭제 여부
+}
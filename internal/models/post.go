@@ -2,37 +2,194 @@ package models
 
 import (
 	"database/sql"
+	"html/template"
+	"strings"
 	"time"
 )
 
+// 파일 악성코드 검사 상태
+const (
+	ScanStatusUnscanned = "unscanned" // 검사기 비활성화 상태에서 업로드됨
+	ScanStatusPending   = "pending"   // 검사 대기 중
+	ScanStatusClean     = "clean"     // 정상
+	ScanStatusInfected  = "infected"  // 악성코드 탐지 (격리됨)
+	ScanStatusError     = "error"     // 검사 실패
+)
+
+// 압축 파일 색인 상태 (압축 파일이 아니면 빈 문자열)
+const (
+	ArchiveStatusPending = "pending" // 색인 대기 중
+	ArchiveStatusIndexed = "indexed" // 항목 목록 저장됨
+	ArchiveStatusError   = "error"   // 읽기 실패 또는 제한 초과
+)
+
 // File 물리적 파일 정보 구조체
 type File struct {
-	ID        int       `json:"id"`
-	FileHash  string    `json:"file_hash"`
-	FilePath  string    `json:"file_path"`
-	FileSize  int64     `json:"file_size"`
-	MimeType  string    `json:"mime_type"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            int       `json:"id"`
+	FileHash      string    `json:"file_hash"`
+	FilePath      string    `json:"file_path"`
+	FileSize      int64     `json:"file_size"`
+	MimeType      string    `json:"mime_type"`
+	ScanStatus    string    `json:"scan_status"`
+	ScanSignature string    `json:"scan_signature,omitempty"`
+	HasThumbnail  bool      `json:"has_thumbnail"`
+	ArchiveStatus string    `json:"archive_status,omitempty"`
+	ArchiveError  string    `json:"archive_error,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// ThumbnailURL 썸네일 경로 (file_hash 기준이므로 중복 파일끼리 공유)
+func (f *File) ThumbnailURL() string {
+	if !f.HasThumbnail || f.IsInfected() {
+		return ""
+	}
+	return "/thumbnails/" + f.FileHash
+}
+
+// IsInfected 악성코드가 탐지되어 격리된 파일인지 확인
+func (f *File) IsInfected() bool {
+	return f.ScanStatus == ScanStatusInfected
 }
 
 // Post 게시글 구조체 (기본 + 관리자용 통합)
 type Post struct {
-	ID         int           `json:"id"`
-	Title      string        `json:"title"`
-	Content    string        `json:"content"`
-	FileName   string        `json:"file_name"`  // 사용자가 업로드한 원본 파일명
-	FileID     sql.NullInt32 `json:"file_id"`    // files 테이블 참조
-	PostType   string        `json:"post_type"`
-	IPAddress  string        `json:"ip_address"`
-	CreatedAt  time.Time     `json:"created_at"`
-	DeletedAt  sql.NullTime  `json:"deleted_at"`
-	
-	// 조인된 파일 정보 (파일 게시글인 경우)
+	ID          int           `json:"id"`
+	Title       string        `json:"title"`
+	Content     string        `json:"content"`
+	ContentHTML template.HTML `json:"-"`         // 마크다운 렌더링 결과 (메시지 게시글)
+	FileName    string        `json:"file_name"` // 사용자가 업로드한 원본 파일명
+	FileID      sql.NullInt32 `json:"file_id"`   // files 테이블 참조
+	PostType    string        `json:"post_type"`
+	IPAddress   string        `json:"ip_address"`
+	CreatedAt   time.Time     `json:"created_at"`
+	DeletedAt   sql.NullTime  `json:"deleted_at"`
+
+	CommentCount int `json:"comment_count"` // 삭제되지 않은 댓글 수
+
+	// 게시판 및 태그
+	BoardID   int      `json:"board_id"`
+	BoardSlug string   `json:"board_slug"`
+	BoardName string   `json:"board_name"`
+	Tags      []string `json:"tags"`
+
+	// 고정 및 공지 (PinOrder가 NULL이면 고정되지 않음)
+	PinOrder       sql.NullInt32 `json:"pin_order"`
+	IsAnnouncement bool          `json:"is_announcement"`
+	AnnounceFrom   sql.NullTime  `json:"announce_from"`
+	AnnounceUntil  sql.NullTime  `json:"announce_until"`
+	Pinned         bool          `json:"pinned"` // 지금 목록 위에 고정되어 표시되는지 (공지 게시 기간 반영)
+
+	// 조인된 파일 정보 (파일 게시글인 경우 대표 파일)
 	File *File `json:"file,omitempty"`
+
+	// 첨부 파일 목록 (post_files, 업로드 순서)
+	Attachments []Attachment `json:"attachments,omitempty"`
 }
 
-// 파일 크기를 MB로 반환
+// Attachment 게시글 첨부 파일 (같은 파일 실체를 여러 게시글이 다른 이름으로 공유 가능)
+type Attachment struct {
+	ID           int    `json:"id"`
+	PostID       int    `json:"post_id"`
+	Position     int    `json:"position"`
+	FileName     string `json:"file_name"`
+	RelativePath string `json:"relative_path,omitempty"` // 폴더 업로드 시 폴더 기준 경로
+	File         *File  `json:"file"`
+
+	ArchiveEntries []ArchiveEntry `json:"archive_entries,omitempty"` // 압축 파일 항목 (게시글 페이지에서만 채움)
+}
+
+// DisplayPath 폴더 업로드면 상대 경로, 아니면 파일명
+func (a *Attachment) DisplayPath() string {
+	if a.RelativePath != "" {
+		return a.RelativePath
+	}
+	return a.FileName
+}
+
+// IsQuarantined 첨부 파일이 격리되어 다운로드할 수 없는지 확인
+func (a *Attachment) IsQuarantined() bool {
+	return a.File != nil && a.File.IsInfected()
+}
+
+// IsScanPending 첨부 파일의 악성코드 검사가 끝나지 않았는지 확인
+func (a *Attachment) IsScanPending() bool {
+	return a.File != nil && a.File.ScanStatus == ScanStatusPending
+}
+
+// IsArchiveIndexed 압축 파일 항목 목록이 저장되었는지 확인
+func (a *Attachment) IsArchiveIndexed() bool {
+	return a.File != nil && a.File.ArchiveStatus == ArchiveStatusIndexed
+}
+
+// FileSizeMB 첨부 파일 크기 (MB)
+func (a *Attachment) FileSizeMB() float64 {
+	if a.File != nil {
+		return float64(a.File.FileSize) / (1024 * 1024)
+	}
+	return 0
+}
+
+// IsAlbum 첨부 파일이 여러 개인 게시글인지 확인
+func (p *Post) IsAlbum() bool {
+	return len(p.Attachments) > 1
+}
+
+// IsFolder 폴더 구조로 업로드된 게시글인지 확인
+func (p *Post) IsFolder() bool {
+	for i := range p.Attachments {
+		if strings.Contains(p.Attachments[i].RelativePath, "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// FolderEntry 폴더 보기의 하위 폴더 (또는 상위 경로 표시)
+type FolderEntry struct {
+	Name      string
+	Path      string
+	FileCount int
+	Size      int64
+}
+
+// SizeMB 폴더 안 파일 크기 합계 (MB)
+func (f *FolderEntry) SizeMB() float64 {
+	return float64(f.Size) / (1024 * 1024)
+}
+
+// FolderListing 게시글 첨부 파일의 폴더 하나에 대한 목록
+type FolderListing struct {
+	Path        string        // 현재 폴더 (최상위는 빈 문자열)
+	Parent      string        // 상위 폴더 (최상위의 하위 폴더이면 빈 문자열)
+	Breadcrumbs []FolderEntry // 최상위부터 현재 폴더까지의 경로
+	Folders     []FolderEntry
+	Files       []Attachment
+}
+
+// IsQuarantined 첨부 파일이 격리되어 다운로드할 수 없는지 확인
+func (p *Post) IsQuarantined() bool {
+	return p.File != nil && p.File.IsInfected()
+}
+
+// IsScanPending 첨부 파일의 악성코드 검사가 끝나지 않았는지 확인
+func (p *Post) IsScanPending() bool {
+	return p.File != nil && p.File.ScanStatus == ScanStatusPending
+}
+
+// IsPinInactive 고정되어 있지만 공지 게시 기간이 아니라 위에 표시되지 않는지 확인
+func (p *Post) IsPinInactive() bool {
+	return p.PinOrder.Valid && !p.Pinned && !p.DeletedAt.Valid
+}
+
+// 파일 크기를 MB로 반환 (첨부 파일이 여러 개면 합계)
 func (p *Post) GetFileSizeMB() float64 {
+	if len(p.Attachments) > 0 {
+		var total float64
+		for i := range p.Attachments {
+			total += p.Attachments[i].FileSizeMB()
+		}
+		return total
+	}
 	if p.File != nil {
 		return float64(p.File.FileSize) / (1024 * 1024)
 	}
@@ -67,6 +224,13 @@ type PostStats struct {
 	MessagePosts int `json:"message_posts"`
 }
 
+// StorageUsage 저장소 사용량 (ReferencedBytes - Bytes가 중복 제거로 절약한 크기)
+type StorageUsage struct {
+	Files           int64 `json:"files"`
+	Bytes           int64 `json:"bytes"`            // 실제 저장된 파일 크기 합계
+	ReferencedBytes int64 `json:"referenced_bytes"` // 게시글이 참조하는 파일 크기 합계 (중복 포함)
+}
+
 // FileUploadRequest 파일 업로드 요청
 type FileUploadRequest struct {
 	Title string
@@ -77,4 +241,45 @@ type FileUploadRequest struct {
 type MessageUploadRequest struct {
 	Title   string `json:"title" binding:"required"`
 	Content string `json:"content"`
+	Board   string `json:"board"` // 게시판 slug (비어 있으면 기본 게시판)
+	Tags    string `json:"tags"`  // 쉼표로 구분한 태그
+}
+
+// PinRequest 관리자 게시글 고정/공지 설정 요청
+type PinRequest struct {
+	Order         *int       `json:"order"` // 비어 있으면 같은 게시판의 마지막 고정 글 다음
+	Announcement  bool       `json:"announcement"`
+	AnnounceFrom  *time.Time `json:"announce_from"`  // 공지 게시 시작 (비어 있으면 즉시, 공지에만 적용)
+	AnnounceUntil *time.Time `json:"announce_until"` // 공지 게시 종료 (비어 있으면 해제할 때까지)
+}
+
+// MarkdownPreviewRequest 마크다운 미리보기 요청
+type MarkdownPreviewRequest struct {
+	Content string `json:"content"`
+}
+
+// BulkPostRequest 관리자 게시글 일괄 처리 요청 (AllMatching이면 IDs 대신 현재 검색 조건에 맞는 게시글 전체)
+type BulkPostRequest struct {
+	Action      string `json:"action" binding:"required"` // delete, restore, unpin
+	IDs         []int  `json:"ids"`
+	AllMatching bool   `json:"all_matching"`
+}
+
+// BulkPostResult 일괄 처리 게시글별 결과
+type BulkPostResult struct {
+	ID      int    `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// PostFilter 관리자 게시글 검색 조건 (내보내기 등에서 사용, 비어 있는 조건은 적용하지 않음)
+type PostFilter struct {
+	PostType  string    `form:"type" json:"type"`                                                     // file, message
+	Status    string    `form:"status" json:"status"`                                                 // active, deleted
+	Query     string    `form:"q" json:"q"`                                                           // 제목/파일명 검색어
+	IPAddress string    `form:"ip" json:"ip"`                                                         // 작성자 IP 또는 CIDR
+	Board     string    `form:"board" json:"board"`                                                   // 게시판 slug
+	Tag       string    `form:"tag" json:"tag"`                                                       // 태그
+	From      time.Time `form:"from" json:"from" time_format:"2006-01-02" time_location:"Asia/Seoul"` // 작성일 시작 (포함)
+	To        time.Time `form:"to" json:"to" time_format:"2006-01-02" time_location:"Asia/Seoul"`     // 작성일 끝 (포함)
 }
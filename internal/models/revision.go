@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// PostRevision 게시글 수정 이력 (수정 직전의 제목/내용/파일명)
+type PostRevision struct {
+	ID        int       `json:"id"`
+	PostID    int       `json:"post_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	FileName  string    `json:"file_name"`
+	EditedIP  string    `json:"edited_ip"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PostEditRequest 관리자 게시글 수정 요청 (내용은 메시지, 파일명은 파일 게시글에만 적용)
+type PostEditRequest struct {
+	Title    string `json:"title"`
+	Content  string `json:"content"`
+	FileName string `json:"file_name"`
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Settings 재시작 없이 바꿀 수 있는 운영 설정 (기본값은 환경 설정, settings 테이블 값이 덮어씀)
+//
+// 한 번 만든 값은 수정하지 않고 통째로 교체하므로 여러 요청에서 동시에 읽어도 안전하다.
+type Settings struct {
+	// 사이트 표시
+	SiteTitle    string `json:"site_title"`    // 게시판 제목 (페이지 제목과 머리글)
+	NoticeBanner string `json:"notice_banner"` // 모든 페이지 위에 표시할 안내 문구 (비어 있으면 표시 안 함)
+
+	// 익명 사용자의 게시글/댓글 작성 허용 (끄면 사용자 게시판은 읽기 전용)
+	AnonymousPosting bool `json:"anonymous_posting"`
+
+	// 업로드 제한
+	UploadsEnabled  bool  `json:"uploads_enabled"`    // 파일 게시글 작성 허용
+	MaxFileSizeMB   int64 `json:"max_file_size_mb"`   // 파일당 최대 크기 (게시판 설정이 더 작으면 게시판 설정 사용)
+	MaxFilesPerPost int   `json:"max_files_per_post"` // 게시글 하나에 첨부할 수 있는 최대 파일 수
+	MaxFolderFiles  int   `json:"max_folder_files"`   // 폴더 업로드 시 최대 파일 수
+	UploadQuotaMB   int64 `json:"upload_quota_mb"`    // IP별 최근 24시간 업로드 한도 (0이면 제한 없음)
+
+	// 업로드를 허용할 파일 확장자 (비어 있으면 모두 허용) 및 거부할 확장자 (점 없이 소문자, 예: exe)
+	AllowedExtensions []string `json:"allowed_extensions"`
+	BlockedExtensions []string `json:"blocked_extensions"`
+
+	// 보관 기간 (일, 지난 게시글은 자동 삭제, 고정 글 제외, 0이면 무기한)
+	// 삭제된 게시글의 첨부 파일은 사용자 서버에서 내려받을 수 없지만 관리자 복원을 위해 파일 실체는 남긴다.
+	RetentionDays int `json:"retention_days"`
+
+	// 댓글
+	CommentsEnabled      bool `json:"comments_enabled"`        // 댓글 작성 허용
+	CommentMaxLength     int  `json:"comment_max_length"`      // 댓글 최대 길이 (글자 수)
+	CommentRateLimit     int  `json:"comment_rate_limit"`      // 작성 제한 기간 동안 IP별 댓글 수 (0이면 제한 없음)
+	CommentRateWindowSec int  `json:"comment_rate_window_sec"` // 작성 제한 기간 (초)
+}
+
+// UploadQuotaWindow 업로드 한도를 계산하는 기간
+const UploadQuotaWindow = 24 * time.Hour
+
+// MaxFileSize 파일당 최대 크기 (바이트)
+func (s *Settings) MaxFileSize() int64 {
+	return s.MaxFileSizeMB * 1024 * 1024
+}
+
+// UploadQuota IP별 업로드 한도 (바이트, 0이면 제한 없음)
+func (s *Settings) UploadQuota() int64 {
+	return s.UploadQuotaMB * 1024 * 1024
+}
+
+// CommentRateWindow 댓글 작성 제한 기간
+func (s *Settings) CommentRateWindow() time.Duration {
+	return time.Duration(s.CommentRateWindowSec) * time.Second
+}
+
+// AcceptsFile 파일 이름의 확장자가 업로드 가능한지 확인 (허용 목록이 있으면 그 안에 있어야 하고, 거부 목록에는 없어야 함)
+func (s *Settings) AcceptsFile(name string) bool {
+	ext := strings.ToLower(strings.TrimPrefix(path.Ext(name), "."))
+	if len(s.AllowedExtensions) > 0 && !containsString(s.AllowedExtensions, ext) {
+		return false
+	}
+	return ext == "" || !containsString(s.BlockedExtensions, ext)
+}
+
+// Normalize 입력 형식 정리 (앞뒤 공백 제거, 확장자 목록을 점 없는 소문자로, 중복 제거)
+func (s *Settings) Normalize() {
+	s.SiteTitle = strings.TrimSpace(s.SiteTitle)
+	s.NoticeBanner = strings.TrimSpace(s.NoticeBanner)
+	s.AllowedExtensions = normalizeExtensions(s.AllowedExtensions)
+	s.BlockedExtensions = normalizeExtensions(s.BlockedExtensions)
+}
+
+func normalizeExtensions(list []string) []string {
+	seen := make(map[string]bool, len(list))
+	extensions := []string{}
+	for _, ext := range list {
+		ext = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(ext), "."))
+		if ext != "" && !seen[ext] {
+			seen[ext] = true
+			extensions = append(extensions, ext)
+		}
+	}
+	return extensions
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate 설정 값 범위 검사
+func (s *Settings) Validate() error {
+	switch {
+	case s.SiteTitle == "" || utf8.RuneCountInString(s.SiteTitle) > 100:
+		return fmt.Errorf("게시판 제목은 1~100자여야 합니다")
+	case utf8.RuneCountInString(s.NoticeBanner) > 1000:
+		return fmt.Errorf("안내 문구는 1000자를 넘을 수 없습니다")
+	case s.RetentionDays < 0 || s.RetentionDays > 36500:
+		return fmt.Errorf("보관 기간은 0~36500일 사이여야 합니다")
+	case s.MaxFileSizeMB < 1:
+		return fmt.Errorf("최대 파일 크기는 1MB 이상이어야 합니다")
+	case s.MaxFileSizeMB > 1<<20:
+		return fmt.Errorf("최대 파일 크기는 %dMB를 넘을 수 없습니다", 1<<20)
+	case s.MaxFilesPerPost < 1 || s.MaxFilesPerPost > 10000:
+		return fmt.Errorf("게시글당 파일 수는 1~10000 사이여야 합니다")
+	case s.MaxFolderFiles < 1 || s.MaxFolderFiles > 100000:
+		return fmt.Errorf("폴더 업로드 파일 수는 1~100000 사이여야 합니다")
+	case s.UploadQuotaMB < 0 || s.UploadQuotaMB > 1<<30:
+		return fmt.Errorf("업로드 한도는 0~%dMB 사이여야 합니다", 1<<30)
+	case s.CommentMaxLength < 1 || s.CommentMaxLength > 100000:
+		return fmt.Errorf("댓글 최대 길이는 1~100000 사이여야 합니다")
+	case s.CommentRateLimit < 0:
+		return fmt.Errorf("댓글 작성 제한 수는 0 이상이어야 합니다")
+	case s.CommentRateWindowSec < 1 || s.CommentRateWindowSec > 86400:
+		return fmt.Errorf("댓글 작성 제한 기간은 1~86400초 사이여야 합니다")
+	}
+	for _, ext := range append(append([]string{}, s.AllowedExtensions...), s.BlockedExtensions...) {
+		if strings.ContainsAny(ext, "./\\ ") || len(ext) > 20 {
+			return fmt.Errorf("잘못된 확장자: %s", ext)
+		}
+	}
+	return nil
+}
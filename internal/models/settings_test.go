@@ -0,0 +1,119 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+// validSettings 검사를 통과하는 설정
+func validSettings() Settings {
+	return Settings{
+		SiteTitle:            "게시판",
+		UploadsEnabled:       true,
+		MaxFileSizeMB:        500,
+		MaxFilesPerPost:      20,
+		MaxFolderFiles:       500,
+		CommentMaxLength:     2000,
+		CommentRateLimit:     5,
+		CommentRateWindowSec: 60,
+	}
+}
+
+func TestSettingsValidate(t *testing.T) {
+	if err := (&Settings{}).Validate(); err == nil {
+		t.Error("Validate() on zero settings succeeded")
+	}
+	valid := validSettings()
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() on valid settings: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(s *Settings)
+	}{
+		{"empty title", func(s *Settings) { s.SiteTitle = "" }},
+		{"long title", func(s *Settings) { s.SiteTitle = strings.Repeat("가", 101) }},
+		{"long banner", func(s *Settings) { s.NoticeBanner = strings.Repeat("가", 1001) }},
+		{"negative retention", func(s *Settings) { s.RetentionDays = -1 }},
+		{"retention too long", func(s *Settings) { s.RetentionDays = 36501 }},
+		{"zero file size", func(s *Settings) { s.MaxFileSizeMB = 0 }},
+		{"huge file size", func(s *Settings) { s.MaxFileSizeMB = 1<<20 + 1 }},
+		{"zero files per post", func(s *Settings) { s.MaxFilesPerPost = 0 }},
+		{"too many folder files", func(s *Settings) { s.MaxFolderFiles = 100001 }},
+		{"negative quota", func(s *Settings) { s.UploadQuotaMB = -1 }},
+		{"zero comment length", func(s *Settings) { s.CommentMaxLength = 0 }},
+		{"negative rate limit", func(s *Settings) { s.CommentRateLimit = -1 }},
+		{"rate window too long", func(s *Settings) { s.CommentRateWindowSec = 86401 }},
+		{"extension with dot", func(s *Settings) { s.AllowedExtensions = []string{"tar.gz"} }},
+		{"extension with slash", func(s *Settings) { s.BlockedExtensions = []string{"a/b"} }},
+		{"long extension", func(s *Settings) { s.BlockedExtensions = []string{strings.Repeat("x", 21)} }},
+	}
+	for _, tt := range tests {
+		s := validSettings()
+		tt.modify(&s)
+		if err := s.Validate(); err == nil {
+			t.Errorf("%s: Validate() succeeded, want error", tt.name)
+		}
+	}
+
+	// 경계값은 허용
+	edge := validSettings()
+	edge.SiteTitle = strings.Repeat("가", 100)
+	edge.RetentionDays = 36500
+	edge.MaxFileSizeMB = 1 << 20
+	edge.CommentRateLimit = 0
+	if err := edge.Validate(); err != nil {
+		t.Errorf("Validate() on boundary values: %v", err)
+	}
+}
+
+func TestSettingsNormalize(t *testing.T) {
+	s := Settings{
+		SiteTitle:         "  게시판 ",
+		NoticeBanner:      "\n점검 안내\n",
+		AllowedExtensions: []string{" .JPG", "jpg", "", "png"},
+		BlockedExtensions: nil,
+	}
+	s.Normalize()
+	if s.SiteTitle != "게시판" || s.NoticeBanner != "점검 안내" {
+		t.Errorf("trimmed = %q, %q", s.SiteTitle, s.NoticeBanner)
+	}
+	if strings.Join(s.AllowedExtensions, ",") != "jpg,png" {
+		t.Errorf("AllowedExtensions = %v, want [jpg png]", s.AllowedExtensions)
+	}
+	if s.BlockedExtensions == nil || len(s.BlockedExtensions) != 0 {
+		t.Errorf("BlockedExtensions = %#v, want empty non-nil list", s.BlockedExtensions)
+	}
+}
+
+func TestSettingsAcceptsFile(t *testing.T) {
+	tests := []struct {
+		allowed, blocked []string
+		name             string
+		want             bool
+	}{
+		{nil, nil, "a.exe", true},
+		{nil, []string{"exe"}, "setup.EXE", false},
+		{nil, []string{"exe"}, "setup.exe.txt", true},
+		{nil, []string{"exe"}, "README", true},
+		{[]string{"jpg", "png"}, nil, "photo.JPG", true},
+		{[]string{"jpg", "png"}, nil, "photo.gif", false},
+		{[]string{"jpg"}, nil, "README", false},
+		{[]string{"jpg"}, []string{"jpg"}, "photo.jpg", false},
+		{nil, nil, "", true},
+	}
+	for _, tt := range tests {
+		s := Settings{AllowedExtensions: tt.allowed, BlockedExtensions: tt.blocked}
+		if got := s.AcceptsFile(tt.name); got != tt.want {
+			t.Errorf("AcceptsFile(%q) with allowed %v, blocked %v = %v, want %v", tt.name, tt.allowed, tt.blocked, got, tt.want)
+		}
+	}
+}
+
+func TestSettingsUnits(t *testing.T) {
+	s := Settings{MaxFileSizeMB: 2, UploadQuotaMB: 3, CommentRateWindowSec: 90}
+	if s.MaxFileSize() != 2<<20 || s.UploadQuota() != 3<<20 || s.CommentRateWindow().Seconds() != 90 {
+		t.Errorf("units = %d, %d, %v", s.MaxFileSize(), s.UploadQuota(), s.CommentRateWindow())
+	}
+}
@@ -0,0 +1,128 @@
+// Package preview 파일 MIME 타입에 따른 미리보기 방식 결정 및 소스 코드 하이라이팅
+package preview
+
+import (
+	"bytes"
+	"html/template"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+)
+
+// MaxTextBytes 텍스트 미리보기로 읽는 최대 크기 (초과분은 잘라서 표시)
+const MaxTextBytes = 1 << 20
+
+// Kind 미리보기 방식
+type Kind string
+
+const (
+	KindText     Kind = "text"
+	KindCode     Kind = "code"
+	KindMarkdown Kind = "markdown"
+	KindPDF      Kind = "pdf"
+	KindImage    Kind = "image"
+	KindAudio    Kind = "audio"
+	KindVideo    Kind = "video"
+	KindDownload Kind = "download" // 미리보기 불가 - 다운로드로 대체
+)
+
+// 텍스트로 취급하는 text/* 이외의 타입
+var textualTypes = map[string]bool{
+	"application/json":       true,
+	"application/xml":        true,
+	"application/javascript": true,
+	"application/x-sh":       true,
+	"application/x-yaml":     true,
+	"application/toml":       true,
+	"application/sql":        true,
+}
+
+// 브라우저에서 안전하게 직접 재생/표시할 수 있는 타입 (SVG 등 스크립트 가능 형식 제외)
+var inlineImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+	"image/webp": true,
+	"image/avif": true,
+	"image/bmp":  true,
+}
+
+// Detect 판별된 MIME 타입과 원본 파일명으로 미리보기 방식 결정
+func Detect(mimeType, fileName string) Kind {
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+	if base, _, found := strings.Cut(mimeType, ";"); found {
+		mimeType = strings.TrimSpace(base)
+	}
+
+	switch {
+	case mimeType == "application/pdf":
+		return KindPDF
+	case inlineImageTypes[mimeType]:
+		return KindImage
+	case strings.HasPrefix(mimeType, "audio/"):
+		return KindAudio
+	case strings.HasPrefix(mimeType, "video/"):
+		return KindVideo
+	case strings.HasPrefix(mimeType, "text/") || textualTypes[mimeType]:
+		return textKind(fileName)
+	}
+	return KindDownload
+}
+
+// IsInline 원본을 인라인으로 제공(/raw)하는 방식인지 확인
+func (k Kind) IsInline() bool {
+	switch k {
+	case KindPDF, KindImage, KindAudio, KindVideo:
+		return true
+	}
+	return false
+}
+
+// textKind 텍스트 파일을 확장자로 마크다운/소스 코드/일반 텍스트로 구분
+func textKind(fileName string) Kind {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".md", ".markdown", ".mdown":
+		return KindMarkdown
+	case ".txt", ".log", "":
+		return KindText
+	}
+	if lexers.Match(fileName) != nil {
+		return KindCode
+	}
+	return KindText
+}
+
+// Highlight 소스 코드를 줄 번호가 있는 HTML과 해당 스타일 CSS로 변환
+func Highlight(source, fileName string) (template.HTML, template.CSS, error) {
+	lexer := lexers.Match(fileName)
+	if lexer == nil {
+		lexer = lexers.Analyse(source)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	style := styles.Get("github")
+	formatter := chromahtml.New(chromahtml.WithClasses(true), chromahtml.WithLineNumbers(true))
+
+	iterator, err := lexer.Tokenise(nil, source)
+	if err != nil {
+		return "", "", err
+	}
+
+	var code, css bytes.Buffer
+	if err := formatter.Format(&code, style, iterator); err != nil {
+		return "", "", err
+	}
+	if err := formatter.WriteCSS(&css, style); err != nil {
+		return "", "", err
+	}
+
+	// chroma 출력은 토큰을 모두 이스케이프하므로 그대로 사용
+	return template.HTML(code.String()), template.CSS(css.String()), nil
+}
@@ -0,0 +1,183 @@
+// Package proxyproto HAProxy PROXY protocol(v1/v2) 헤더를 해석하는 리스너
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 헤더 수신 대기 시간 (느린 연결이 서버 고루틴을 붙잡지 않도록)
+const headerTimeout = 10 * time.Second
+
+// v2 시그니처
+var v2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// Listener PROXY protocol 헤더를 해석하는 net.Listener 래퍼
+type Listener struct {
+	net.Listener
+	// Trusted 헤더를 신뢰할 연결 상대인지 판단 (nil이면 모든 상대 허용)
+	Trusted func(ip net.IP) bool
+}
+
+// NewListener PROXY protocol 리스너 생성
+func NewListener(inner net.Listener, trusted func(ip net.IP) bool) *Listener {
+	return &Listener{Listener: inner, Trusted: trusted}
+}
+
+// Accept 연결 수락 (헤더는 첫 Read/RemoteAddr 호출 시 연결 고루틴에서 해석)
+func (l *Listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &Conn{Conn: conn, reader: bufio.NewReader(conn), trusted: l.Trusted}, nil
+}
+
+// Conn PROXY protocol 헤더가 제거된 연결
+type Conn struct {
+	net.Conn
+	reader     *bufio.Reader
+	trusted    func(ip net.IP) bool
+	once       sync.Once
+	remoteAddr net.Addr
+	err        error
+}
+
+// Read 헤더를 해석한 뒤 나머지 데이터를 읽음
+func (c *Conn) Read(b []byte) (int, error) {
+	c.once.Do(c.readHeader)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.reader.Read(b)
+}
+
+// RemoteAddr 헤더에 기록된 원본 주소 (없으면 실제 연결 주소)
+func (c *Conn) RemoteAddr() net.Addr {
+	c.once.Do(c.readHeader)
+	if c.remoteAddr != nil {
+		return c.remoteAddr
+	}
+	return c.Conn.RemoteAddr()
+}
+
+// readHeader 연결 시작 부분의 PROXY 헤더 해석
+func (c *Conn) readHeader() {
+	if c.trusted != nil {
+		if addr, ok := c.Conn.RemoteAddr().(*net.TCPAddr); ok && !c.trusted(addr.IP) {
+			// 신뢰하지 않는 상대가 보낸 헤더는 해석하지 않음
+			return
+		}
+	}
+
+	c.Conn.SetReadDeadline(time.Now().Add(headerTimeout))
+	defer c.Conn.SetReadDeadline(time.Time{})
+
+	first, err := c.reader.Peek(1)
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			c.err = err
+		}
+		return
+	}
+
+	switch first[0] {
+	case 'P':
+		if prefix, err := c.reader.Peek(6); err == nil && string(prefix) == "PROXY " {
+			c.remoteAddr, c.err = c.readV1()
+		}
+	case '\r':
+		if prefix, err := c.reader.Peek(len(v2Signature)); err == nil && bytes.Equal(prefix, v2Signature) {
+			c.remoteAddr, c.err = c.readV2()
+		}
+	}
+}
+
+// readV1 텍스트 형식 헤더 해석: "PROXY TCP4 src dst sport dport\r\n"
+func (c *Conn) readV1() (net.Addr, error) {
+	// v1 헤더는 CRLF 포함 최대 107바이트
+	var line []byte
+	for len(line) < 107 {
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("PROXY v1 헤더 읽기 실패: %v", err)
+		}
+		line = append(line, b)
+		if bytes.HasSuffix(line, []byte("\r\n")) {
+			break
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, errors.New("PROXY v1 헤더가 너무 깁니다")
+	}
+
+	fields := strings.Fields(string(line))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, fmt.Errorf("잘못된 PROXY v1 헤더: %q", strings.TrimSpace(string(line)))
+	}
+
+	ip := net.ParseIP(fields[2])
+	port, err := strconv.Atoi(fields[4])
+	if ip == nil || err != nil || port < 0 || port > 65535 {
+		return nil, fmt.Errorf("잘못된 PROXY v1 주소: %s:%s", fields[2], fields[4])
+	}
+	return &net.TCPAddr{IP: ip, Port: port}, nil
+}
+
+// readV2 바이너리 형식 헤더 해석
+func (c *Conn) readV2() (net.Addr, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(c.reader, header); err != nil {
+		return nil, fmt.Errorf("PROXY v2 헤더 읽기 실패: %v", err)
+	}
+
+	verCmd, family := header[12], header[13]
+	length := int(binary.BigEndian.Uint16(header[14:16]))
+	if verCmd>>4 != 2 {
+		return nil, fmt.Errorf("지원하지 않는 PROXY 버전: %d", verCmd>>4)
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return nil, fmt.Errorf("PROXY v2 주소 읽기 실패: %v", err)
+	}
+
+	// LOCAL 명령(헬스체크 등)은 실제 연결 주소를 그대로 사용
+	if verCmd&0x0F == 0 {
+		return nil, nil
+	}
+
+	switch family >> 4 {
+	case 1: // AF_INET
+		if len(payload) < 12 {
+			return nil, errors.New("PROXY v2 IPv4 주소 길이 부족")
+		}
+		return &net.TCPAddr{
+			IP:   net.IP(payload[0:4]),
+			Port: int(binary.BigEndian.Uint16(payload[8:10])),
+		}, nil
+	case 2: // AF_INET6
+		if len(payload) < 36 {
+			return nil, errors.New("PROXY v2 IPv6 주소 길이 부족")
+		}
+		return &net.TCPAddr{
+			IP:   net.IP(payload[0:16]),
+			Port: int(binary.BigEndian.Uint16(payload[32:34])),
+		}, nil
+	default:
+		// AF_UNSPEC, AF_UNIX 등은 원래 주소 유지
+		return nil, nil
+	}
+}
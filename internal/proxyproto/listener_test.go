@@ -0,0 +1,195 @@
+package proxyproto
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// roundTrip 리스너로 연결해 raw 바이트를 보내고, 서버 쪽에서 본 원격 주소와 본문(또는 읽기 오류) 반환
+func roundTrip(t *testing.T, trusted func(ip net.IP) bool, raw []byte) (net.Addr, string, error) {
+	t.Helper()
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	listener := NewListener(inner, trusted)
+	defer listener.Close()
+
+	client, err := net.Dial("tcp", inner.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer client.Close()
+	go func() {
+		client.Write(raw)
+		client.(*net.TCPConn).CloseWrite()
+	}()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("accept: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	addr := conn.RemoteAddr()
+	body, err := io.ReadAll(conn)
+	return addr, string(body), err
+}
+
+// v2Header PROXY v2 헤더 생성 (command: 0 LOCAL, 1 PROXY)
+func v2Header(command, family byte, payload []byte) []byte {
+	header := append([]byte{}, v2Signature...)
+	header = append(header, 0x20|command, family, 0, 0)
+	binary.BigEndian.PutUint16(header[14:16], uint16(len(payload)))
+	return append(header, payload...)
+}
+
+func v2IPv4Payload(src, dst string, srcPort, dstPort uint16) []byte {
+	payload := make([]byte, 12)
+	copy(payload[0:4], net.ParseIP(src).To4())
+	copy(payload[4:8], net.ParseIP(dst).To4())
+	binary.BigEndian.PutUint16(payload[8:10], srcPort)
+	binary.BigEndian.PutUint16(payload[10:12], dstPort)
+	return payload
+}
+
+func v2IPv6Payload(src, dst string, srcPort, dstPort uint16) []byte {
+	payload := make([]byte, 36)
+	copy(payload[0:16], net.ParseIP(src).To16())
+	copy(payload[16:32], net.ParseIP(dst).To16())
+	binary.BigEndian.PutUint16(payload[32:34], srcPort)
+	binary.BigEndian.PutUint16(payload[34:36], dstPort)
+	return payload
+}
+
+func trustAll(net.IP) bool  { return true }
+func trustNone(net.IP) bool { return false }
+
+func TestListener(t *testing.T) {
+	const body = "GET / HTTP/1.1\r\n\r\n"
+	tests := []struct {
+		name     string
+		trusted  func(ip net.IP) bool
+		raw      []byte
+		wantAddr string // 비어 있으면 실제 연결 주소(127.0.0.1)
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "v1 tcp4",
+			trusted:  trustAll,
+			raw:      []byte("PROXY TCP4 198.51.100.7 10.0.0.1 4711 80\r\n" + body),
+			wantAddr: "198.51.100.7:4711",
+			wantBody: body,
+		},
+		{
+			name:     "v1 tcp6",
+			trusted:  trustAll,
+			raw:      []byte("PROXY TCP6 2001:db8::7 2001:db8::1 4711 443\r\n" + body),
+			wantAddr: "[2001:db8::7]:4711",
+			wantBody: body,
+		},
+		{
+			name:     "v1 unknown keeps peer",
+			trusted:  trustAll,
+			raw:      []byte("PROXY UNKNOWN\r\n" + body),
+			wantBody: body,
+		},
+		{name: "v1 bad address", trusted: trustAll, raw: []byte("PROXY TCP4 not-an-ip 10.0.0.1 4711 80\r\n" + body), wantErr: true},
+		{name: "v1 bad port", trusted: trustAll, raw: []byte("PROXY TCP4 198.51.100.7 10.0.0.1 99999 80\r\n" + body), wantErr: true},
+		{name: "v1 missing fields", trusted: trustAll, raw: []byte("PROXY TCP4 198.51.100.7\r\n" + body), wantErr: true},
+		{name: "v1 too long", trusted: trustAll, raw: append([]byte("PROXY TCP4 "), bytes.Repeat([]byte("1"), 200)...), wantErr: true},
+		{
+			name:     "v2 ipv4",
+			trusted:  trustAll,
+			raw:      append(v2Header(1, 0x11, v2IPv4Payload("198.51.100.7", "10.0.0.1", 4711, 80)), body...),
+			wantAddr: "198.51.100.7:4711",
+			wantBody: body,
+		},
+		{
+			name:     "v2 ipv6",
+			trusted:  trustAll,
+			raw:      append(v2Header(1, 0x21, v2IPv6Payload("2001:db8::7", "2001:db8::1", 4711, 443)), body...),
+			wantAddr: "[2001:db8::7]:4711",
+			wantBody: body,
+		},
+		{
+			name:     "v2 with TLVs after addresses",
+			trusted:  trustAll,
+			raw:      append(v2Header(1, 0x11, append(v2IPv4Payload("198.51.100.7", "10.0.0.1", 4711, 80), 0x04, 0x00, 0x01, 0xff)), body...),
+			wantAddr: "198.51.100.7:4711",
+			wantBody: body,
+		},
+		{
+			name:     "v2 local command keeps peer",
+			trusted:  trustAll,
+			raw:      append(v2Header(0, 0x00, nil), body...),
+			wantBody: body,
+		},
+		{
+			name:     "v2 unspec family keeps peer",
+			trusted:  trustAll,
+			raw:      append(v2Header(1, 0x00, nil), body...),
+			wantBody: body,
+		},
+		{name: "v2 wrong version", trusted: trustAll, raw: append(append(append([]byte{}, v2Signature...), 0x11, 0x11, 0, 0), body...), wantErr: true},
+		{name: "v2 short ipv4 payload", trusted: trustAll, raw: append(v2Header(1, 0x11, []byte{1, 2, 3}), body...), wantErr: true},
+		{name: "v2 short ipv6 payload", trusted: trustAll, raw: append(v2Header(1, 0x21, v2IPv4Payload("198.51.100.7", "10.0.0.1", 1, 2)), body...), wantErr: true},
+		{name: "v2 truncated header", trusted: trustAll, raw: append(append([]byte{}, v2Signature...), 0x21), wantErr: true},
+		{name: "v2 length beyond data", trusted: trustAll, raw: append(v2Header(1, 0x11, nil)[:14], 0xff, 0xff), wantErr: true},
+		{
+			name:     "trusted peer without header",
+			trusted:  trustAll,
+			raw:      []byte(body),
+			wantBody: body,
+		},
+		{
+			name:     "untrusted peer header is not parsed",
+			trusted:  trustNone,
+			raw:      []byte("PROXY TCP4 198.51.100.7 10.0.0.1 4711 80\r\n" + body),
+			wantBody: "PROXY TCP4 198.51.100.7 10.0.0.1 4711 80\r\n" + body,
+		},
+		{
+			name:     "untrusted peer v2 header is not parsed",
+			trusted:  trustNone,
+			raw:      v2Header(1, 0x11, v2IPv4Payload("198.51.100.7", "10.0.0.1", 4711, 80)),
+			wantBody: string(v2Header(1, 0x11, v2IPv4Payload("198.51.100.7", "10.0.0.1", 4711, 80))),
+		},
+		{
+			name:     "empty connection",
+			trusted:  trustAll,
+			raw:      nil,
+			wantBody: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, got, err := roundTrip(t, tt.trusted, tt.raw)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("read error = nil, want error (addr %v, body %q)", addr, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("read: %v", err)
+			}
+			if got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+			if tt.wantAddr != "" {
+				if addr.String() != tt.wantAddr {
+					t.Errorf("RemoteAddr = %s, want %s", addr, tt.wantAddr)
+				}
+			} else if host, _, _ := net.SplitHostPort(addr.String()); host != "127.0.0.1" {
+				t.Errorf("RemoteAddr = %s, want the peer address", addr)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Package scanner ClamAV clamd INSTREAM 프로토콜 클라이언트
+package scanner
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// 전송 청크 크기 (clamd StreamMaxLength와 별개로 한 번에 보내는 단위)
+const chunkSize = 64 * 1024
+
+// Result 검사 결과
+type Result struct {
+	Infected  bool
+	Signature string // 감염 시 탐지된 시그니처 이름
+}
+
+// Scanner clamd 클라이언트
+type Scanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// New 주소 문자열로 클라이언트 생성
+//
+// 지원 형식: "tcp://host:3310", "unix:///run/clamav/clamd.sock", "host:3310"
+func New(address string, timeout time.Duration) (*Scanner, error) {
+	network := "tcp"
+	switch {
+	case strings.HasPrefix(address, "tcp://"):
+		address = strings.TrimPrefix(address, "tcp://")
+	case strings.HasPrefix(address, "unix://"):
+		network = "unix"
+		address = strings.TrimPrefix(address, "unix://")
+	case strings.HasPrefix(address, "/"):
+		network = "unix"
+	}
+
+	if address == "" {
+		return nil, fmt.Errorf("clamd 주소가 비어 있습니다")
+	}
+	if network == "tcp" {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return nil, fmt.Errorf("잘못된 clamd 주소: %s", address)
+		}
+	}
+
+	return &Scanner{network: network, address: address, timeout: timeout}, nil
+}
+
+// Address 접속 대상 표시용 문자열
+func (s *Scanner) Address() string {
+	return s.network + "://" + s.address
+}
+
+// Ping clamd 응답 확인
+func (s *Scanner) Ping() error {
+	reply, err := s.command("zPING\x00", nil)
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("예상하지 못한 clamd 응답: %s", reply)
+	}
+	return nil
+}
+
+// Scan 스트림 내용을 INSTREAM 명령으로 검사
+func (s *Scanner) Scan(r io.Reader) (Result, error) {
+	reply, err := s.command("zINSTREAM\x00", r)
+	if err != nil {
+		return Result{}, err
+	}
+	return parseReply(reply)
+}
+
+// command 명령 전송 후 NUL로 끝나는 응답 한 줄을 읽음 (body가 있으면 청크 단위로 전송)
+func (s *Scanner) command(cmd string, body io.Reader) (string, error) {
+	conn, err := net.DialTimeout(s.network, s.address, s.timeout)
+	if err != nil {
+		return "", fmt.Errorf("clamd 연결 실패: %v", err)
+	}
+	defer conn.Close()
+
+	if s.timeout > 0 {
+		conn.SetDeadline(time.Now().Add(s.timeout))
+	}
+
+	if _, err := io.WriteString(conn, cmd); err != nil {
+		return "", fmt.Errorf("clamd 명령 전송 실패: %v", err)
+	}
+
+	if body != nil {
+		if err := s.sendChunks(conn, body); err != nil {
+			return "", err
+		}
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && !(err == io.EOF && reply != "") {
+		return "", fmt.Errorf("clamd 응답 읽기 실패: %v", err)
+	}
+	return strings.TrimSpace(strings.TrimRight(reply, "\x00")), nil
+}
+
+// sendChunks 4바이트 길이 헤더 + 데이터 형식으로 전송하고 길이 0 청크로 종료
+func (s *Scanner) sendChunks(conn net.Conn, body io.Reader) error {
+	buf := make([]byte, chunkSize)
+	header := make([]byte, 4)
+	for {
+		n, readErr := body.Read(buf)
+		if n > 0 {
+			if s.timeout > 0 {
+				// 큰 파일은 전송 시간이 길어지므로 청크마다 데드라인 연장
+				conn.SetDeadline(time.Now().Add(s.timeout))
+			}
+			binary.BigEndian.PutUint32(header, uint32(n))
+			if _, err := conn.Write(header); err != nil {
+				return fmt.Errorf("clamd 전송 실패: %v", err)
+			}
+			if _, err := conn.Write(buf[:n]); err != nil {
+				return fmt.Errorf("clamd 전송 실패: %v", err)
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("검사 대상 읽기 실패: %v", readErr)
+		}
+	}
+
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("clamd 전송 종료 실패: %v", err)
+	}
+	return nil
+}
+
+// parseReply "stream: OK", "stream: <시그니처> FOUND", "... ERROR" 응답 해석
+func parseReply(reply string) (Result, error) {
+	// 세션 ID 접두사("1: stream: OK")가 붙는 경우도 처리
+	if idx := strings.LastIndex(reply, ": "); idx >= 0 && !strings.HasSuffix(reply, "ERROR") {
+		status := reply[idx+2:]
+		switch {
+		case status == "OK":
+			return Result{}, nil
+		case strings.HasSuffix(status, " FOUND"):
+			return Result{Infected: true, Signature: strings.TrimSuffix(status, " FOUND")}, nil
+		}
+	}
+	return Result{}, fmt.Errorf("clamd 검사 오류: %s", reply)
+}
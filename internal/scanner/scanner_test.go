@@ -0,0 +1,187 @@
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClamd INSTREAM 명령 하나를 받아 청크를 기록하고 정해진 응답을 보내는 가짜 clamd
+type fakeClamd struct {
+	listener net.Listener
+	done     chan struct{}
+
+	command string
+	chunks  []int  // 받은 청크 길이 (종료 청크 제외)
+	data    []byte // 받은 데이터 전체
+	ended   bool   // 길이 0 종료 청크 수신 여부
+	err     error
+}
+
+func newFakeClamd(t *testing.T, reply string) *fakeClamd {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	f := &fakeClamd{listener: listener, done: make(chan struct{})}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		defer close(f.done)
+		conn, err := listener.Accept()
+		if err != nil {
+			f.err = err
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+
+		f.command, f.err = reader.ReadString(0)
+		if f.err != nil {
+			return
+		}
+		header := make([]byte, 4)
+		for {
+			if _, f.err = io.ReadFull(reader, header); f.err != nil {
+				return
+			}
+			size := binary.BigEndian.Uint32(header)
+			if size == 0 {
+				f.ended = true
+				break
+			}
+			chunk := make([]byte, size)
+			if _, f.err = io.ReadFull(reader, chunk); f.err != nil {
+				return
+			}
+			f.chunks = append(f.chunks, int(size))
+			f.data = append(f.data, chunk...)
+		}
+		io.WriteString(conn, reply+"\x00")
+	}()
+	return f
+}
+
+// wait 가짜 clamd 처리가 끝날 때까지 대기
+func (f *fakeClamd) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-f.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("fake clamd did not finish")
+	}
+	if f.err != nil {
+		t.Fatalf("fake clamd: %v", f.err)
+	}
+}
+
+func TestScanChunkFraming(t *testing.T) {
+	fake := newFakeClamd(t, "stream: OK")
+	s, err := New(fake.listener.Addr().String(), 5*time.Second)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	body := bytes.Repeat([]byte("a"), chunkSize+10)
+	if _, err := s.Scan(bytes.NewReader(body)); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	fake.wait(t)
+
+	if fake.command != "zINSTREAM\x00" {
+		t.Errorf("command = %q, want %q", fake.command, "zINSTREAM\x00")
+	}
+	if len(fake.chunks) != 2 || fake.chunks[0] != chunkSize || fake.chunks[1] != 10 {
+		t.Errorf("chunks = %v, want [%d 10]", fake.chunks, chunkSize)
+	}
+	if !fake.ended {
+		t.Error("zero-length terminator chunk not received")
+	}
+	if !bytes.Equal(fake.data, body) {
+		t.Error("received data does not match scanned body")
+	}
+}
+
+func TestScanEmptyBody(t *testing.T) {
+	fake := newFakeClamd(t, "stream: OK")
+	s, err := New("tcp://"+fake.listener.Addr().String(), 5*time.Second)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	if _, err := s.Scan(strings.NewReader("")); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	fake.wait(t)
+
+	if len(fake.chunks) != 0 || !fake.ended {
+		t.Errorf("chunks = %v, ended = %v, want only the terminator", fake.chunks, fake.ended)
+	}
+}
+
+func TestScanReplies(t *testing.T) {
+	tests := []struct {
+		name      string
+		reply     string
+		infected  bool
+		signature string
+		wantErr   bool
+	}{
+		{name: "clean", reply: "stream: OK"},
+		{name: "clean with session id", reply: "1: stream: OK"},
+		{name: "found", reply: "stream: Eicar-Test-Signature FOUND", infected: true, signature: "Eicar-Test-Signature"},
+		{name: "found with spaces", reply: "stream: Win.Test.EICAR_HDB-1 FOUND", infected: true, signature: "Win.Test.EICAR_HDB-1"},
+		{name: "size limit error", reply: "INSTREAM size limit exceeded. ERROR", wantErr: true},
+		{name: "scan error", reply: "stream: Can't allocate memory ERROR", wantErr: true},
+		{name: "unknown reply", reply: "UNKNOWN COMMAND", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeClamd(t, tt.reply)
+			s, err := New(fake.listener.Addr().String(), 5*time.Second)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+
+			result, err := s.Scan(strings.NewReader("X5O!P%@AP[4\\PZX54(P^)7CC)7}$EICAR"))
+			fake.wait(t)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Scan(%q) error = nil, want error", tt.reply)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan(%q): %v", tt.reply, err)
+			}
+			if result.Infected != tt.infected || result.Signature != tt.signature {
+				t.Errorf("Scan(%q) = %+v, want infected=%v signature=%q", tt.reply, result, tt.infected, tt.signature)
+			}
+		})
+	}
+}
+
+func TestScanConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	address := listener.Addr().String()
+	listener.Close()
+
+	s, err := New(address, time.Second)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	if _, err := s.Scan(strings.NewReader("data")); err == nil {
+		t.Fatal("Scan error = nil, want connection error")
+	}
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"file-board/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// 한 번에 ZIP으로 받을 수 있는 최대 게시글 수 (일반 사용자)
+const MaxArchivePosts = 100
+
+// GetArchiveFiles 선택한 게시글들의 첨부 파일 목록 조회 (격리/검사 중인 파일 제외, 선택 순서 유지)
+func (s *PostService) GetArchiveFiles(ctx context.Context, postIDs []int, includeDeleted bool) ([]models.Attachment, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.BulkTimeout)
+	defer cancel()
+
+	ids := make([]int64, len(postIDs))
+	for i, id := range postIDs {
+		ids[i] = int64(id)
+	}
+
+	where := "p.id = ANY($1)"
+	if !includeDeleted {
+		where += " AND p.deleted_at IS NULL"
+	}
+	return s.queryArchiveFiles(ctx, where, "array_position($1, p.id::bigint), pf.position", pq.Array(ids))
+}
+
+// GetArchiveFilesByFilter 검색 조건에 맞는 게시글들의 첨부 파일 목록 조회 (관리자 내보내기)
+func (s *PostService) GetArchiveFilesByFilter(ctx context.Context, filter models.PostFilter) ([]models.Attachment, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.BulkTimeout)
+	defer cancel()
+
+	where, args, err := postFilterClause(filter)
+	if err != nil {
+		return nil, err
+	}
+	return s.queryArchiveFiles(ctx, where, "p.created_at DESC, pf.position", args...)
+}
+
+// queryArchiveFiles 첨부 파일 조회 공통 쿼리
+func (s *PostService) queryArchiveFiles(ctx context.Context, where, orderBy string, args ...interface{}) ([]models.Attachment, error) {
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT pf.id, pf.post_id, pf.position, pf.file_name, COALESCE(pf.relative_path, ''),
+		       f.id, f.file_hash, f.file_path, f.file_size, COALESCE(f.mime_type, ''), f.created_at
+		FROM posts p
+		JOIN post_files pf ON pf.post_id = p.id
+		JOIN files f ON pf.file_id = f.id
+		WHERE %s AND f.scan_status NOT IN ('infected', 'pending')
+		ORDER BY %s
+	`, where, orderBy), args...)
+	if err != nil {
+		return nil, fmt.Errorf("첨부 파일 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	var attachments []models.Attachment
+	for rows.Next() {
+		attachment := models.Attachment{File: &models.File{}}
+		if err := rows.Scan(
+			&attachment.ID, &attachment.PostID, &attachment.Position, &attachment.FileName, &attachment.RelativePath,
+			&attachment.File.ID, &attachment.File.FileHash, &attachment.File.FilePath,
+			&attachment.File.FileSize, &attachment.File.MimeType, &attachment.File.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("첨부 파일 스캔 실패: %v", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, rows.Err()
+}
+
+// postFilterClause 검색 조건을 posts(p) 기준 WHERE 절과 인자로 변환
+func postFilterClause(filter models.PostFilter) (string, []interface{}, error) {
+	conditions := []string{"TRUE"}
+	var args []interface{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	switch filter.PostType {
+	case "":
+	case "file", "message":
+		conditions = append(conditions, "p.post_type = "+arg(filter.PostType))
+	default:
+		return "", nil, fmt.Errorf("잘못된 게시글 종류: %s", filter.PostType)
+	}
+
+	switch filter.Status {
+	case "":
+	case "active":
+		conditions = append(conditions, "p.deleted_at IS NULL")
+	case "deleted":
+		conditions = append(conditions, "p.deleted_at IS NOT NULL")
+	default:
+		return "", nil, fmt.Errorf("잘못된 게시글 상태: %s", filter.Status)
+	}
+
+	if query := strings.TrimSpace(filter.Query); query != "" {
+		pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(query) + "%"
+		placeholder := arg(pattern)
+		conditions = append(conditions, fmt.Sprintf(
+			"(p.title ILIKE %[1]s OR p.file_name ILIKE %[1]s OR EXISTS (SELECT 1 FROM post_files q WHERE q.post_id = p.id AND q.file_name ILIKE %[1]s))",
+			placeholder,
+		))
+	}
+
+	if filter.Board != "" {
+		conditions = append(conditions, "p.board_id = (SELECT id FROM boards WHERE slug = "+arg(filter.Board)+")")
+	}
+
+	if tag := strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(filter.Tag), "#"))); tag != "" {
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM post_tags pt JOIN tags t ON pt.tag_id = t.id WHERE pt.post_id = p.id AND t.name = %s)",
+			arg(tag),
+		))
+	}
+
+	if filter.IPAddress != "" {
+		cidr, err := NormalizeCIDR(filter.IPAddress)
+		if err != nil {
+			return "", nil, err
+		}
+		// 주소로 해석되지 않는 값은 try_inet이 NULL을 반환하므로 캐스팅 오류 없이 제외됨
+		conditions = append(conditions, fmt.Sprintf(
+			"try_inet(p.ip_address) IS NOT NULL AND try_inet(p.ip_address) <<= %s::cidr", arg(cidr),
+		))
+	}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "p.created_at >= "+arg(filter.From))
+	}
+	if !filter.To.IsZero() {
+		// 종료일 당일 전체 포함
+		conditions = append(conditions, "p.created_at < "+arg(filter.To.Add(24*time.Hour)))
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
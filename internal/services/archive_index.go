@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"file-board/internal/archive"
+	"file-board/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// JobArchiveIndex 압축 파일 항목 색인 작업
+const JobArchiveIndex = "archive_index"
+
+// ErrArchiveNotIndexed 압축 파일이 아니거나 항목 목록이 아직 준비되지 않음
+var ErrArchiveNotIndexed = errors.New("압축 파일 항목 목록이 준비되지 않았습니다")
+
+type archiveIndexPayload struct {
+	FileID int `json:"file_id"`
+}
+
+// requestArchiveIndex 압축 파일 색인 작업을 큐에 추가
+func (s *PostService) requestArchiveIndex(ctx context.Context, fileID int) {
+	if s.jobs == nil {
+		return
+	}
+	if _, err := s.jobs.Enqueue(JobArchiveIndex, archiveIndexPayload{FileID: fileID}); err != nil {
+		slog.ErrorContext(ctx, "압축 파일 색인 작업 추가 실패", "file_id", fileID, "error", err)
+	}
+}
+
+// HandleArchiveIndexJob 압축 파일 색인 작업 처리 (제한 초과나 손상된 파일은 재시도하지 않고 에러 상태로 기록)
+func (s *PostService) HandleArchiveIndexJob(ctx context.Context, job *models.Job) error {
+	var payload archiveIndexPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return fmt.Errorf("압축 파일 색인 작업 데이터 해석 실패: %v", err)
+	}
+
+	var filePath, mimeType, scanStatus, archiveStatus string
+	err := s.db.QueryRowContext(ctx, `
+		SELECT file_path, COALESCE(mime_type, ''), scan_status, COALESCE(archive_status, '')
+		FROM files WHERE id = $1
+	`, payload.FileID).Scan(&filePath, &mimeType, &scanStatus, &archiveStatus)
+	if err == sql.ErrNoRows {
+		// 게시글 저장에 실패해 정리된 파일
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("색인 대상 파일 조회 실패: %v", err)
+	}
+
+	// 이미 색인되었거나, 격리되었거나, 지원하지 않는 형식이면 건너뜀
+	format := archive.Detect(mimeType)
+	if archiveStatus == models.ArchiveStatusIndexed || scanStatus == models.ScanStatusInfected || format == "" {
+		return nil
+	}
+
+	entries, err := archive.List(filePath, format, s.archiveLimits())
+	if err != nil {
+		slog.WarnContext(ctx, "압축 파일 색인 실패", "file_id", payload.FileID, "error", err)
+		if _, err := s.db.ExecContext(ctx, `
+			UPDATE files SET archive_status = $2, archive_error = $3 WHERE id = $1
+		`, payload.FileID, models.ArchiveStatusError, err.Error()); err != nil {
+			return fmt.Errorf("색인 상태 저장 실패: %v", err)
+		}
+		return nil
+	}
+
+	return s.saveArchiveEntries(ctx, payload.FileID, entries)
+}
+
+// saveArchiveEntries 항목 목록 저장 (재실행 시 기존 목록을 교체)
+func (s *PostService) saveArchiveEntries(ctx context.Context, fileID int, entries []archive.Entry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM archive_entries WHERE file_id = $1", fileID); err != nil {
+		return fmt.Errorf("기존 항목 삭제 실패: %v", err)
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("archive_entries",
+		"file_id", "entry_index", "name", "size", "compressed_size", "modified_at", "is_dir"))
+	if err != nil {
+		return fmt.Errorf("항목 저장 준비 실패: %v", err)
+	}
+	for _, entry := range entries {
+		var modifiedAt sql.NullTime
+		if !entry.ModifiedAt.IsZero() {
+			modifiedAt = sql.NullTime{Time: entry.ModifiedAt, Valid: true}
+		}
+		if _, err := stmt.ExecContext(ctx, fileID, entry.Index, entry.Name, entry.Size, entry.CompressedSize, modifiedAt, entry.IsDir); err != nil {
+			stmt.Close()
+			return fmt.Errorf("항목 저장 실패: %v", err)
+		}
+	}
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		stmt.Close()
+		return fmt.Errorf("항목 저장 실패: %v", err)
+	}
+	if err := stmt.Close(); err != nil {
+		return fmt.Errorf("항목 저장 실패: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE files SET archive_status = $2, archive_error = NULL WHERE id = $1
+	`, fileID, models.ArchiveStatusIndexed); err != nil {
+		return fmt.Errorf("색인 상태 저장 실패: %v", err)
+	}
+
+	return tx.Commit()
+}
+
+// loadArchiveEntries 색인된 압축 파일 첨부의 항목 목록을 한 번에 조회하여 채움
+func (s *PostService) loadArchiveEntries(ctx context.Context, attachments []models.Attachment) error {
+	index := make(map[int][]*models.Attachment)
+	var ids []int64
+	for i := range attachments {
+		if attachments[i].IsArchiveIndexed() && !attachments[i].IsQuarantined() {
+			fileID := attachments[i].File.ID
+			if _, ok := index[fileID]; !ok {
+				ids = append(ids, int64(fileID))
+			}
+			index[fileID] = append(index[fileID], &attachments[i])
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, file_id, entry_index, name, size, compressed_size, modified_at, is_dir
+		FROM archive_entries
+		WHERE file_id = ANY($1)
+		ORDER BY file_id, entry_index
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("압축 파일 항목 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry models.ArchiveEntry
+		if err := rows.Scan(
+			&entry.ID, &entry.FileID, &entry.Index, &entry.Name,
+			&entry.Size, &entry.CompressedSize, &entry.ModifiedAt, &entry.IsDir,
+		); err != nil {
+			return fmt.Errorf("압축 파일 항목 스캔 실패: %v", err)
+		}
+		// 같은 파일이 여러 번 첨부된 경우 모두 채움
+		for _, attachment := range index[entry.FileID] {
+			attachment.ArchiveEntries = append(attachment.ArchiveEntries, entry)
+		}
+	}
+	return rows.Err()
+}
+
+// GetArchiveEntry 첨부 압축 파일의 항목 하나 조회 (격리/검사 중인 파일은 에러 반환, includeDeleted는 관리자 서버만 true)
+func (s *PostService) GetArchiveEntry(ctx context.Context, id, position, entryIndex int, includeDeleted bool) (*models.ArchiveEntry, *models.File, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.QueryTimeout)
+	defer cancel()
+
+	file, err := s.getFileByPosition(ctx, id, position, includeDeleted)
+	if err != nil {
+		return nil, nil, err
+	}
+	if file.ArchiveStatus != models.ArchiveStatusIndexed {
+		return nil, nil, ErrArchiveNotIndexed
+	}
+
+	entry := models.ArchiveEntry{FileID: file.ID}
+	err = s.db.QueryRowContext(ctx, `
+		SELECT id, entry_index, name, size, compressed_size, modified_at, is_dir
+		FROM archive_entries
+		WHERE file_id = $1 AND entry_index = $2
+	`, file.ID, entryIndex).Scan(
+		&entry.ID, &entry.Index, &entry.Name, &entry.Size, &entry.CompressedSize, &entry.ModifiedAt, &entry.IsDir,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if entry.IsDir {
+		return nil, nil, sql.ErrNoRows
+	}
+	return &entry, file, nil
+}
+
+// ExtractArchiveEntry 압축 파일 전체를 풀지 않고 항목 하나만 해제하여 w에 기록
+func (s *PostService) ExtractArchiveEntry(file *models.File, entryIndex int, w io.Writer) error {
+	return archive.Extract(file.FilePath, archive.Detect(file.MimeType), entryIndex,
+		s.cfg.Archive.MaxEntrySize, s.archiveLimits(), w)
+}
+
+// getFileByPosition 게시글 첨부 파일의 실체 조회 (격리/검사 중인 파일은 에러 반환, 삭제된 게시글은 includeDeleted일 때만)
+func (s *PostService) getFileByPosition(ctx context.Context, id, position int, includeDeleted bool) (*models.File, error) {
+	query := `
+		SELECT f.id, f.file_path, COALESCE(f.mime_type, ''), f.scan_status, COALESCE(f.archive_status, '')
+		FROM posts p
+		JOIN post_files pf ON pf.post_id = p.id AND pf.position = $2
+		JOIN files f ON pf.file_id = f.id
+		WHERE p.id = $1 AND p.post_type = 'file'`
+	if !includeDeleted {
+		query += " AND p.deleted_at IS NULL"
+	}
+
+	var file models.File
+	err := s.db.QueryRowContext(ctx, query, id, position).Scan(&file.ID, &file.FilePath, &file.MimeType, &file.ScanStatus, &file.ArchiveStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	switch file.ScanStatus {
+	case models.ScanStatusInfected:
+		return nil, ErrFileQuarantined
+	case models.ScanStatusPending:
+		return nil, ErrFileScanPending
+	}
+	return &file, nil
+}
+
+// archiveLimits 설정값으로 압축 폭탄 방지 제한 구성
+func (s *PostService) archiveLimits() archive.Limits {
+	return archive.Limits{
+		MaxEntries:   s.cfg.Archive.MaxEntries,
+		MaxTotalSize: s.cfg.Archive.MaxTotalSize,
+		MaxRatio:     s.cfg.Archive.MaxRatio,
+	}
+}
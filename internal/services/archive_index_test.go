@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"file-board/internal/config"
+	"file-board/internal/models"
+)
+
+func TestGetArchiveEntryHidesDeletedPosts(t *testing.T) {
+	db := openTestDB(t)
+	s := &PostService{db: db, cfg: &config.Config{}}
+	ctx := context.Background()
+
+	fileID := insertFile(t, db, "00000000000000c1", "c1.zip", models.ScanStatusClean)
+	if _, err := db.Exec(`UPDATE files SET archive_status = $2 WHERE id = $1`, fileID, models.ArchiveStatusIndexed); err != nil {
+		t.Fatalf("update file: %v", err)
+	}
+	if _, err := db.Exec(`
+		INSERT INTO archive_entries (file_id, entry_index, name, size, compressed_size, is_dir)
+		VALUES ($1, 0, 'a.txt', 5, 5, FALSE)
+	`, fileID); err != nil {
+		t.Fatalf("insert archive entry: %v", err)
+	}
+	postID := insertFilePost(t, db, fileID, "c1.zip")
+
+	if entry, _, err := s.GetArchiveEntry(ctx, postID, 0, 0, false); err != nil || entry.Name != "a.txt" {
+		t.Fatalf("GetArchiveEntry(live) = %+v, %v", entry, err)
+	}
+
+	if _, err := db.Exec("UPDATE posts SET deleted_at = NOW() WHERE id = $1", postID); err != nil {
+		t.Fatalf("delete post: %v", err)
+	}
+	if _, _, err := s.GetArchiveEntry(ctx, postID, 0, 0, false); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetArchiveEntry(deleted) error = %v, want sql.ErrNoRows", err)
+	}
+	if entry, _, err := s.GetArchiveEntry(ctx, postID, 0, 0, true); err != nil || entry.Name != "a.txt" {
+		t.Errorf("GetArchiveEntry(deleted, includeDeleted) = %+v, %v", entry, err)
+	}
+}
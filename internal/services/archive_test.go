@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"file-board/internal/config"
+	"file-board/internal/models"
+)
+
+const searchClause = "(p.title ILIKE %[1]s OR p.file_name ILIKE %[1]s OR EXISTS (SELECT 1 FROM post_files q WHERE q.post_id = p.id AND q.file_name ILIKE %[1]s))"
+
+func TestPostFilterClause(t *testing.T) {
+	from := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 8, 31, 0, 0, 0, 0, time.UTC)
+	search := func(placeholder string) string {
+		return strings.ReplaceAll(searchClause, "%[1]s", placeholder)
+	}
+
+	tests := []struct {
+		name   string
+		filter models.PostFilter
+		where  string
+		args   []interface{}
+	}{
+		{
+			name:   "empty",
+			filter: models.PostFilter{},
+			where:  "TRUE",
+		},
+		{
+			name:   "type and deleted status",
+			filter: models.PostFilter{PostType: "file", Status: "deleted"},
+			where:  "TRUE AND p.post_type = $1 AND p.deleted_at IS NOT NULL",
+			args:   []interface{}{"file"},
+		},
+		{
+			name:   "active status has no argument",
+			filter: models.PostFilter{Status: "active", IPAddress: "203.0.113.7"},
+			where:  "TRUE AND p.deleted_at IS NULL AND try_inet(p.ip_address) IS NOT NULL AND try_inet(p.ip_address) <<= $1::cidr",
+			args:   []interface{}{"203.0.113.7/32"},
+		},
+		{
+			name:   "search escapes LIKE wildcards and reuses one placeholder",
+			filter: models.PostFilter{PostType: "message", Query: `  50%_off\  `},
+			where:  "TRUE AND p.post_type = $1 AND " + search("$2"),
+			args:   []interface{}{"message", `%50\%\_off\\%`},
+		},
+		{
+			name:   "ip range and date range",
+			filter: models.PostFilter{IPAddress: "203.0.113.77/24", From: from, To: to},
+			where: "TRUE AND try_inet(p.ip_address) IS NOT NULL AND try_inet(p.ip_address) <<= $1::cidr" +
+				" AND p.created_at >= $2 AND p.created_at < $3",
+			args: []interface{}{"203.0.113.0/24", from, to.Add(24 * time.Hour)},
+		},
+		{
+			name: "all conditions",
+			filter: models.PostFilter{
+				PostType: "file", Status: "active", Query: "report", Board: "free", Tag: "#Go",
+				IPAddress: "2001:db8::1", From: from, To: to,
+			},
+			where: "TRUE AND p.post_type = $1 AND p.deleted_at IS NULL AND " + search("$2") +
+				" AND p.board_id = (SELECT id FROM boards WHERE slug = $3)" +
+				" AND EXISTS (SELECT 1 FROM post_tags pt JOIN tags t ON pt.tag_id = t.id WHERE pt.post_id = p.id AND t.name = $4)" +
+				" AND try_inet(p.ip_address) IS NOT NULL AND try_inet(p.ip_address) <<= $5::cidr" +
+				" AND p.created_at >= $6 AND p.created_at < $7",
+			args: []interface{}{"file", "%report%", "free", "go", "2001:db8::1/128", from, to.Add(24 * time.Hour)},
+		},
+		{
+			name:   "only end date",
+			filter: models.PostFilter{To: to},
+			where:  "TRUE AND p.created_at < $1",
+			args:   []interface{}{to.Add(24 * time.Hour)},
+		},
+		{
+			name:   "blank search and tag are ignored",
+			filter: models.PostFilter{Query: "   ", Tag: " # "},
+			where:  "TRUE",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args, err := postFilterClause(tt.filter)
+			if err != nil {
+				t.Fatalf("postFilterClause: %v", err)
+			}
+			if where != tt.where {
+				t.Errorf("where =\n  %s\nwant\n  %s", where, tt.where)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %#v, want %#v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestPostFilterClauseInvalid(t *testing.T) {
+	invalid := []models.PostFilter{
+		{PostType: "image"},
+		{Status: "archived"},
+		{IPAddress: "not-an-ip"},
+		{IPAddress: "203.0.113.0/33"},
+	}
+	for _, filter := range invalid {
+		if where, _, err := postFilterClause(filter); err == nil {
+			t.Errorf("postFilterClause(%+v) = %q, want error", filter, where)
+		}
+	}
+}
+
+func TestGetArchiveFilesByFilter(t *testing.T) {
+	db := openTestDB(t)
+	s := &PostService{db: db, cfg: &config.Config{}}
+
+	inRange := insertFilePost(t, db, insertFile(t, db, "00000000000000d1", "d1.bin", models.ScanStatusClean), "in.txt")
+	insertFilePost(t, db, insertFile(t, db, "00000000000000d2", "d2.bin", models.ScanStatusClean), "out.txt")
+	if _, err := db.Exec("UPDATE posts SET ip_address = '198.51.100.7' WHERE id <> $1", inRange); err != nil {
+		t.Fatalf("update posts: %v", err)
+	}
+	insertPost(t, db, "message", "unknown", sql.NullInt64{})
+
+	files, err := s.GetArchiveFilesByFilter(context.Background(), models.PostFilter{IPAddress: "203.0.113.0/24", Query: "in"})
+	if err != nil {
+		t.Fatalf("GetArchiveFilesByFilter: %v", err)
+	}
+	if len(files) != 1 || files[0].PostID != inRange {
+		t.Errorf("files = %+v, want only post %d", files, inRange)
+	}
+}
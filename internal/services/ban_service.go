@@ -0,0 +1,125 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"file-board/internal/models"
+)
+
+type BanService struct {
+	db *sql.DB
+}
+
+func NewBanService(db *sql.DB) *BanService {
+	return &BanService{db: db}
+}
+
+// GetBans 차단 목록 조회 (만료된 항목 포함, 최신순)
+func (s *BanService) GetBans() ([]models.IPBan, error) {
+	rows, err := s.db.Query(`
+		SELECT id, cidr::text, COALESCE(reason, ''), expires_at, created_at
+		FROM ip_bans
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("차단 목록 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	var bans []models.IPBan
+	for rows.Next() {
+		var ban models.IPBan
+		if err := rows.Scan(&ban.ID, &ban.CIDR, &ban.Reason, &ban.ExpiresAt, &ban.CreatedAt); err != nil {
+			return nil, fmt.Errorf("차단 목록 스캔 실패: %v", err)
+		}
+		bans = append(bans, ban)
+	}
+
+	return bans, rows.Err()
+}
+
+// FindActiveBan 해당 IP에 적용되는 유효한 차단 조회 (없으면 nil)
+func (s *BanService) FindActiveBan(ipAddress string) (*models.IPBan, error) {
+	if net.ParseIP(ipAddress) == nil {
+		return nil, nil
+	}
+
+	var ban models.IPBan
+	err := s.db.QueryRow(`
+		SELECT id, cidr::text, COALESCE(reason, ''), expires_at, created_at
+		FROM ip_bans
+		WHERE $1::inet <<= cidr AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY expires_at DESC NULLS FIRST
+		LIMIT 1
+	`, ipAddress).Scan(&ban.ID, &ban.CIDR, &ban.Reason, &ban.ExpiresAt, &ban.CreatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("차단 여부 확인 실패: %v", err)
+	}
+	return &ban, nil
+}
+
+// CreateBan IP/CIDR 차단 추가 (duration이 0이면 영구 차단)
+func (s *BanService) CreateBan(cidr, reason string, duration time.Duration) (*models.IPBan, error) {
+	normalized, err := NormalizeCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt sql.NullTime
+	if duration > 0 {
+		expiresAt = sql.NullTime{Time: time.Now().Add(duration), Valid: true}
+	}
+
+	ban := models.IPBan{CIDR: normalized, Reason: reason, ExpiresAt: expiresAt}
+	err = s.db.QueryRow(`
+		INSERT INTO ip_bans (cidr, reason, expires_at)
+		VALUES ($1, $2, $3) RETURNING id, created_at
+	`, normalized, reason, expiresAt).Scan(&ban.ID, &ban.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("차단 추가 실패: %v", err)
+	}
+
+	return &ban, nil
+}
+
+// DeleteBan 차단 해제
+func (s *BanService) DeleteBan(id int) error {
+	result, err := s.db.Exec("DELETE FROM ip_bans WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("차단 해제 실패: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("차단 해제 결과 확인 실패: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("차단 항목을 찾을 수 없습니다")
+	}
+	return nil
+}
+
+// NormalizeCIDR 단일 IP 또는 CIDR 문자열을 네트워크 주소 형태의 CIDR로 변환
+func NormalizeCIDR(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if ip := net.ParseIP(value); ip != nil {
+		if ip.To4() != nil {
+			return ip.String() + "/32", nil
+		}
+		return ip.String() + "/128", nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(value)
+	if err != nil {
+		return "", fmt.Errorf("잘못된 IP 또는 CIDR: %s", value)
+	}
+	return ipNet.String(), nil
+}
@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"file-board/internal/config"
+)
+
+func TestNormalizeCIDR(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "203.0.113.7", want: "203.0.113.7/32"},
+		{input: "  203.0.113.7  ", want: "203.0.113.7/32"},
+		{input: "203.0.113.7/24", want: "203.0.113.0/24"},
+		{input: "10.0.0.0/8", want: "10.0.0.0/8"},
+		{input: "::ffff:203.0.113.7", want: "203.0.113.7/32"},
+		{input: "2001:db8::1", want: "2001:db8::1/128"},
+		{input: "2001:db8::1/32", want: "2001:db8::/32"},
+		{input: "", wantErr: true},
+		{input: "example.com", wantErr: true},
+		{input: "203.0.113.7/33", wantErr: true},
+		{input: "203.0.113.256", wantErr: true},
+		{input: "203.0.113.0/24; DROP TABLE posts", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeCIDR(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("NormalizeCIDR(%q) = %q, want error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NormalizeCIDR(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("NormalizeCIDR(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestFindActiveBan(t *testing.T) {
+	db := openTestDB(t)
+	bans := NewBanService(db)
+
+	if _, err := bans.CreateBan("203.0.113.0/24", "range", 0); err != nil {
+		t.Fatalf("CreateBan: %v", err)
+	}
+	if _, err := bans.CreateBan("2001:db8::1", "single", time.Hour); err != nil {
+		t.Fatalf("CreateBan: %v", err)
+	}
+	if _, err := db.Exec(`INSERT INTO ip_bans (cidr, reason, expires_at) VALUES ('198.51.100.0/24', 'expired', NOW() - INTERVAL '1 minute')`); err != nil {
+		t.Fatalf("insert expired ban: %v", err)
+	}
+
+	tests := []struct {
+		ip     string
+		reason string // 빈 문자열이면 차단 없음
+	}{
+		{ip: "203.0.113.200", reason: "range"},
+		{ip: "203.0.114.1"},
+		{ip: "2001:db8::1", reason: "single"},
+		{ip: "2001:db8::2"},
+		{ip: "198.51.100.5"}, // 만료된 차단
+		{ip: "not-an-ip"},    // 주소가 아니면 조회하지 않음
+		{ip: ""},
+	}
+	for _, tt := range tests {
+		ban, err := bans.FindActiveBan(tt.ip)
+		if err != nil {
+			t.Errorf("FindActiveBan(%q): %v", tt.ip, err)
+			continue
+		}
+		switch {
+		case tt.reason == "" && ban != nil:
+			t.Errorf("FindActiveBan(%q) = %+v, want no ban", tt.ip, ban)
+		case tt.reason != "" && (ban == nil || ban.Reason != tt.reason):
+			t.Errorf("FindActiveBan(%q) = %+v, want reason %q", tt.ip, ban, tt.reason)
+		}
+	}
+}
+
+func TestDeletePostsByCIDR(t *testing.T) {
+	db := openTestDB(t)
+	posts := &PostService{db: db, cfg: &config.Config{}}
+
+	inRange := insertPost(t, db, "message", "203.0.113.7", sql.NullInt64{})
+	outOfRange := insertPost(t, db, "message", "198.51.100.7", sql.NullInt64{})
+	invalid := insertPost(t, db, "message", "unknown", sql.NullInt64{})
+	empty := insertPost(t, db, "message", "", sql.NullInt64{})
+
+	deleted, err := posts.DeletePostsByCIDR(context.Background(), "203.0.113.0/24")
+	if err != nil {
+		t.Fatalf("DeletePostsByCIDR: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+
+	for id, wantDeleted := range map[int]bool{inRange: true, outOfRange: false, invalid: false, empty: false} {
+		var isDeleted bool
+		if err := db.QueryRow("SELECT deleted_at IS NOT NULL FROM posts WHERE id = $1", id).Scan(&isDeleted); err != nil {
+			t.Fatalf("select post %d: %v", id, err)
+		}
+		if isDeleted != wantDeleted {
+			t.Errorf("post %d deleted = %v, want %v", id, isDeleted, wantDeleted)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"file-board/internal/config"
+	"file-board/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrBoardPostTypeNotAllowed 게시판에서 허용하지 않는 게시글 종류
+var ErrBoardPostTypeNotAllowed = errors.New("이 게시판에는 해당 종류의 게시글을 작성할 수 없습니다")
+
+// 게시판 주소 이름 형식 (소문자, 숫자, 하이픈)
+var boardSlugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+
+type BoardService struct {
+	db       *sql.DB
+	cfg      *config.Config
+	settings *SettingsService
+}
+
+func NewBoardService(db *sql.DB, cfg *config.Config, settings *SettingsService) *BoardService {
+	return &BoardService{db: db, cfg: cfg, settings: settings}
+}
+
+// GetBoards 게시판 목록 조회 (순서대로, 삭제되지 않은 게시글 수 포함)
+func (s *BoardService) GetBoards() ([]models.Board, error) {
+	rows, err := s.db.Query(`
+		SELECT b.id, b.slug, b.name, b.description, b.allow_files, b.allow_messages,
+		       b.max_file_size, b.position, b.created_at,
+		       (SELECT COUNT(*) FROM posts p WHERE p.board_id = b.id AND p.deleted_at IS NULL)
+		FROM boards b
+		ORDER BY b.position, b.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("게시판 목록 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	var boards []models.Board
+	for rows.Next() {
+		var board models.Board
+		if err := rows.Scan(
+			&board.ID, &board.Slug, &board.Name, &board.Description, &board.AllowFiles, &board.AllowMessages,
+			&board.MaxFileSize, &board.Position, &board.CreatedAt, &board.PostCount,
+		); err != nil {
+			return nil, fmt.Errorf("게시판 목록 스캔 실패: %v", err)
+		}
+		boards = append(boards, board)
+	}
+	return boards, rows.Err()
+}
+
+// ResolveBoard slug로 게시판 조회 (slug가 비어 있으면 기본 게시판, 없으면 sql.ErrNoRows)
+func (s *BoardService) ResolveBoard(slug string) (*models.Board, error) {
+	where, args := "TRUE", []interface{}{}
+	if slug != "" {
+		where, args = "slug = $1", []interface{}{slug}
+	}
+
+	var board models.Board
+	err := s.db.QueryRow(fmt.Sprintf(`
+		SELECT id, slug, name, description, allow_files, allow_messages, max_file_size, position, created_at
+		FROM boards
+		WHERE %s
+		ORDER BY position, id
+		LIMIT 1
+	`, where), args...).Scan(
+		&board.ID, &board.Slug, &board.Name, &board.Description, &board.AllowFiles, &board.AllowMessages,
+		&board.MaxFileSize, &board.Position, &board.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
+
+// CreateBoard 게시판 생성
+func (s *BoardService) CreateBoard(req models.BoardRequest) (*models.Board, error) {
+	board, err := s.validateBoard(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.db.QueryRow(`
+		INSERT INTO boards (slug, name, description, allow_files, allow_messages, max_file_size, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, created_at
+	`, board.Slug, board.Name, board.Description, board.AllowFiles, board.AllowMessages,
+		board.MaxFileSize, board.Position).Scan(&board.ID, &board.CreatedAt)
+	if isUniqueViolation(err) {
+		return nil, fmt.Errorf("이미 사용 중인 주소 이름입니다: %s", board.Slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("게시판 생성 실패: %v", err)
+	}
+	return board, nil
+}
+
+// UpdateBoard 게시판 설정 수정
+func (s *BoardService) UpdateBoard(id int, req models.BoardRequest) (*models.Board, error) {
+	board, err := s.validateBoard(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.Exec(`
+		UPDATE boards
+		SET slug = $2, name = $3, description = $4, allow_files = $5, allow_messages = $6,
+		    max_file_size = $7, position = $8
+		WHERE id = $1
+	`, id, board.Slug, board.Name, board.Description, board.AllowFiles, board.AllowMessages,
+		board.MaxFileSize, board.Position)
+	if isUniqueViolation(err) {
+		return nil, fmt.Errorf("이미 사용 중인 주소 이름입니다: %s", board.Slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("게시판 수정 실패: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("수정 결과 확인 실패: %v", err)
+	}
+	if rowsAffected == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	board.ID = id
+	return board, nil
+}
+
+// DeleteBoard 게시판 삭제 (게시글이 남아 있거나 마지막 게시판이면 삭제 불가)
+func (s *BoardService) DeleteBoard(id int) error {
+	var postCount, boardCount int
+	err := s.db.QueryRow(`
+		SELECT (SELECT COUNT(*) FROM posts WHERE board_id = $1), (SELECT COUNT(*) FROM boards)
+	`, id).Scan(&postCount, &boardCount)
+	if err != nil {
+		return fmt.Errorf("게시판 확인 실패: %v", err)
+	}
+	if postCount > 0 {
+		return fmt.Errorf("게시글이 %d개 남아 있는 게시판은 삭제할 수 없습니다", postCount)
+	}
+	if boardCount <= 1 {
+		return fmt.Errorf("마지막 게시판은 삭제할 수 없습니다")
+	}
+
+	result, err := s.db.Exec("DELETE FROM boards WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("게시판 삭제 실패: %v", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("삭제 결과 확인 실패: %v", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// validateBoard 게시판 요청 검증 및 정규화
+func (s *BoardService) validateBoard(req models.BoardRequest) (*models.Board, error) {
+	board := &models.Board{
+		Slug:          strings.ToLower(strings.TrimSpace(req.Slug)),
+		Name:          strings.TrimSpace(req.Name),
+		Description:   strings.TrimSpace(req.Description),
+		AllowFiles:    req.AllowFiles,
+		AllowMessages: req.AllowMessages,
+		MaxFileSize:   req.MaxFileSizeMB * 1024 * 1024,
+		Position:      req.Position,
+	}
+
+	if !boardSlugPattern.MatchString(board.Slug) {
+		return nil, fmt.Errorf("주소 이름은 영문 소문자, 숫자, 하이픈으로 50자 이내여야 합니다")
+	}
+	if board.Name == "" || utf8.RuneCountInString(board.Name) > 100 {
+		return nil, fmt.Errorf("게시판 이름은 1~100자여야 합니다")
+	}
+	if !board.AllowFiles && !board.AllowMessages {
+		return nil, fmt.Errorf("파일 또는 메시지 중 하나 이상을 허용해야 합니다")
+	}
+	if settings := s.settings.Current(); req.MaxFileSizeMB < 0 || board.MaxFileSize > settings.MaxFileSize() {
+		return nil, fmt.Errorf("최대 파일 크기는 0~%dMB 사이여야 합니다", settings.MaxFileSizeMB)
+	}
+	return board, nil
+}
+
+// isUniqueViolation UNIQUE 제약 조건 위반 에러인지 확인
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
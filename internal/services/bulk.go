@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"file-board/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// MaxBulkPosts 한 번에 일괄 처리할 수 있는 최대 게시글 수
+const MaxBulkPosts = 5000
+
+// bulkAction 일괄 처리 종류별 변경 내용과 적용 조건
+type bulkAction struct {
+	setClause string
+	condition string // 이미 처리된 게시글 제외
+	label     string
+	skipped   string // 조건에 맞지 않을 때 게시글별 사유
+}
+
+var bulkActions = map[string]bulkAction{
+	"delete": {
+		setClause: "deleted_at = NOW()",
+		condition: "deleted_at IS NULL",
+		label:     "삭제",
+		skipped:   "이미 삭제된 게시글입니다",
+	},
+	"restore": {
+		setClause: "deleted_at = NULL",
+		condition: "deleted_at IS NOT NULL",
+		label:     "복구",
+		skipped:   "삭제되지 않은 게시글입니다",
+	},
+	"unpin": {
+		setClause: "pin_order = NULL, is_announcement = FALSE, announce_from = NULL, announce_until = NULL",
+		condition: "pin_order IS NOT NULL",
+		label:     "고정 해제",
+		skipped:   "고정되지 않은 게시글입니다",
+	},
+}
+
+// BulkActionLabel 일괄 처리 종류의 표시 이름 (지원하지 않으면 빈 문자열)
+func BulkActionLabel(action string) string {
+	return bulkActions[action].label
+}
+
+// BulkUpdatePosts 게시글 일괄 처리 (filter가 있으면 ids 대신 조건에 맞는 게시글 전체, 하나의 트랜잭션으로 실행)
+func (s *PostService) BulkUpdatePosts(ctx context.Context, action string, ids []int, filter *models.PostFilter) ([]models.BulkPostResult, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.BulkTimeout)
+	defer cancel()
+
+	spec, ok := bulkActions[action]
+	if !ok {
+		return nil, fmt.Errorf("지원하지 않는 일괄 처리입니다: %s", action)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	if filter != nil {
+		whereClause, args, err := postFilterClause(*filter)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+			SELECT p.id FROM posts p WHERE %s ORDER BY p.id LIMIT %d
+		`, whereClause, MaxBulkPosts+1), args...)
+		if err != nil {
+			return nil, fmt.Errorf("대상 게시글 조회 실패: %v", err)
+		}
+		ids = nil
+		for rows.Next() {
+			var id int
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("대상 게시글 스캔 실패: %v", err)
+			}
+			ids = append(ids, id)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("대상 게시글 조회 실패: %v", err)
+		}
+	}
+
+	ids = uniqueInts(ids)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("처리할 게시글이 없습니다")
+	}
+	if len(ids) > MaxBulkPosts {
+		return nil, fmt.Errorf("한 번에 최대 %d개 게시글까지 처리할 수 있습니다. 조건을 좁혀주세요", MaxBulkPosts)
+	}
+
+	targets := make([]int64, len(ids))
+	for i, id := range ids {
+		targets[i] = int64(id)
+	}
+
+	// 존재 여부 확인과 동시에 잠금 (처리 중 다른 변경 방지)
+	existing, err := queryIDSet(ctx, tx, "SELECT id FROM posts WHERE id = ANY($1) FOR UPDATE", pq.Array(targets))
+	if err != nil {
+		return nil, fmt.Errorf("대상 게시글 확인 실패: %v", err)
+	}
+	updated, err := queryIDSet(ctx, tx, fmt.Sprintf(
+		"UPDATE posts SET %s WHERE id = ANY($1) AND %s RETURNING id", spec.setClause, spec.condition,
+	), pq.Array(targets))
+	if err != nil {
+		return nil, fmt.Errorf("게시글 일괄 %s 실패: %v", spec.label, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("게시글 일괄 %s 실패: %v", spec.label, err)
+	}
+
+	results := make([]models.BulkPostResult, len(ids))
+	for i, id := range ids {
+		results[i] = models.BulkPostResult{ID: id, Success: updated[id]}
+		switch {
+		case updated[id]:
+		case existing[id]:
+			results[i].Error = spec.skipped
+		default:
+			results[i].Error = "게시글을 찾을 수 없습니다"
+		}
+	}
+	return results, nil
+}
+
+// queryIDSet id 하나를 반환하는 쿼리 결과를 집합으로 수집
+func queryIDSet(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) (map[int]bool, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	set := make(map[int]bool)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		set[id] = true
+	}
+	return set, rows.Err()
+}
+
+// uniqueInts 순서를 유지하며 중복 제거
+func uniqueInts(values []int) []int {
+	seen := make(map[int]bool, len(values))
+	result := make([]int, 0, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"file-board/internal/config"
+	"file-board/internal/models"
+)
+
+var (
+	// ErrCommentRateLimited 같은 IP에서 짧은 시간에 너무 많은 댓글 작성
+	ErrCommentRateLimited = errors.New("댓글을 너무 자주 작성하고 있습니다. 잠시 후 다시 시도해주세요")
+	// ErrCommentTargetNotFound 댓글을 달 게시글 또는 답글 대상 댓글이 없음
+	ErrCommentTargetNotFound = errors.New("댓글을 작성할 게시글 또는 댓글을 찾을 수 없습니다")
+	// ErrCommentsDisabled 관리자가 댓글 작성을 중지함
+	ErrCommentsDisabled = errors.New("현재 댓글 작성이 중지되어 있습니다")
+	// ErrCommentPostNotFound 댓글을 조회할 게시글이 없거나 삭제됨
+	ErrCommentPostNotFound = errors.New("게시글을 찾을 수 없습니다")
+)
+
+type CommentService struct {
+	db       *sql.DB
+	cfg      *config.Config
+	settings *SettingsService
+}
+
+func NewCommentService(db *sql.DB, cfg *config.Config, settings *SettingsService) *CommentService {
+	return &CommentService{db: db, cfg: cfg, settings: settings}
+}
+
+// GetComments 게시글의 댓글을 답글 트리로 조회 (일반 사용자용 - 삭제된 댓글은 답글이 있을 때만 자리 표시)
+func (s *CommentService) GetComments(postID int) ([]*models.Comment, error) {
+	// 삭제된 게시글의 댓글은 공개하지 않음
+	var exists bool
+	err := s.db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM posts WHERE id = $1 AND deleted_at IS NULL)
+	`, postID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("게시글 확인 실패: %v", err)
+	}
+	if !exists {
+		return nil, ErrCommentPostNotFound
+	}
+	return s.getComments(postID, false)
+}
+
+// GetAllComments 게시글의 댓글을 답글 트리로 조회 (관리자용 - 삭제된 댓글 및 IP 포함)
+func (s *CommentService) GetAllComments(postID int) ([]*models.Comment, error) {
+	return s.getComments(postID, true)
+}
+
+// getComments 댓글 조회 후 parent_id 기준으로 트리 구성
+func (s *CommentService) getComments(postID int, includeDeleted bool) ([]*models.Comment, error) {
+	rows, err := s.db.Query(`
+		SELECT id, post_id, parent_id, content, COALESCE(ip_address, ''), created_at, deleted_at
+		FROM comments
+		WHERE post_id = $1
+		ORDER BY created_at, id
+	`, postID)
+	if err != nil {
+		return nil, fmt.Errorf("댓글 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	var comments []*models.Comment
+	for rows.Next() {
+		var comment models.Comment
+		if err := rows.Scan(
+			&comment.ID, &comment.PostID, &comment.ParentID, &comment.Content,
+			&comment.IPAddress, &comment.CreatedAt, &comment.DeletedAt,
+		); err != nil {
+			return nil, fmt.Errorf("댓글 스캔 실패: %v", err)
+		}
+		comment.Deleted = comment.DeletedAt.Valid
+		comment.Replies = []*models.Comment{}
+		if !includeDeleted {
+			comment.IPAddress = ""
+			if comment.Deleted {
+				comment.Content = ""
+			}
+		}
+		comments = append(comments, &comment)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("댓글 조회 실패: %v", err)
+	}
+
+	tree := buildCommentTree(comments)
+	if !includeDeleted {
+		tree = pruneDeletedComments(tree)
+	}
+	return tree, nil
+}
+
+// buildCommentTree 작성순으로 정렬된 댓글 목록을 답글 트리로 변환
+func buildCommentTree(comments []*models.Comment) []*models.Comment {
+	byID := make(map[int]*models.Comment, len(comments))
+	for _, comment := range comments {
+		byID[comment.ID] = comment
+	}
+
+	roots := []*models.Comment{}
+	for _, comment := range comments {
+		parent, ok := byID[int(comment.ParentID.Int32)]
+		if comment.ParentID.Valid && ok {
+			parent.Replies = append(parent.Replies, comment)
+		} else {
+			roots = append(roots, comment)
+		}
+	}
+	return roots
+}
+
+// pruneDeletedComments 보이는 답글이 없는 삭제된 댓글 제거 (답글이 있으면 스레드 유지를 위해 남김)
+func pruneDeletedComments(comments []*models.Comment) []*models.Comment {
+	kept := []*models.Comment{}
+	for _, comment := range comments {
+		comment.Replies = pruneDeletedComments(comment.Replies)
+		if comment.Deleted && len(comment.Replies) == 0 {
+			continue
+		}
+		kept = append(kept, comment)
+	}
+	return kept
+}
+
+// CreateComment 댓글 작성 (parentID가 0이면 최상위 댓글, IP별 작성 빈도 제한)
+func (s *CommentService) CreateComment(postID, parentID int, content, ipAddress string) (*models.Comment, error) {
+	settings := s.settings.Current()
+	if !settings.CommentsEnabled {
+		return nil, ErrCommentsDisabled
+	}
+
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil, fmt.Errorf("댓글 내용을 입력해주세요")
+	}
+	if utf8.RuneCountInString(content) > settings.CommentMaxLength {
+		return nil, fmt.Errorf("댓글은 %d자를 초과할 수 없습니다", settings.CommentMaxLength)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.checkRateLimit(tx, ipAddress, settings); err != nil {
+		return nil, err
+	}
+
+	// 삭제되지 않은 게시글에만 작성 가능
+	var exists bool
+	err = tx.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM posts WHERE id = $1 AND deleted_at IS NULL)
+	`, postID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("게시글 확인 실패: %v", err)
+	}
+	if !exists {
+		return nil, ErrCommentTargetNotFound
+	}
+
+	// 답글 대상은 같은 게시글의 삭제되지 않은 댓글이어야 함
+	var parent sql.NullInt32
+	if parentID != 0 {
+		err := tx.QueryRow(`
+			SELECT EXISTS (SELECT 1 FROM comments WHERE id = $1 AND post_id = $2 AND deleted_at IS NULL)
+		`, parentID, postID).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("답글 대상 확인 실패: %v", err)
+		}
+		if !exists {
+			return nil, ErrCommentTargetNotFound
+		}
+		parent = sql.NullInt32{Int32: int32(parentID), Valid: true}
+	}
+
+	comment := models.Comment{
+		PostID:   postID,
+		ParentID: parent,
+		Content:  content,
+		Replies:  []*models.Comment{},
+	}
+	err = tx.QueryRow(`
+		INSERT INTO comments (post_id, parent_id, content, ip_address)
+		VALUES ($1, $2, $3, $4) RETURNING id, created_at
+	`, postID, parent, content, ipAddress).Scan(&comment.ID, &comment.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("댓글 저장 실패: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("댓글 저장 실패: %v", err)
+	}
+	return &comment, nil
+}
+
+// checkRateLimit 제한 기간 안에 같은 IP에서 작성한 댓글 수 확인
+//
+// 같은 IP의 요청은 트랜잭션 단위 advisory lock으로 직렬화하므로, 동시에 들어온
+// 요청도 앞선 요청의 댓글이 커밋된 뒤에 개수를 센다.
+func (s *CommentService) checkRateLimit(tx *sql.Tx, ipAddress string, settings *models.Settings) error {
+	if settings.CommentRateLimit <= 0 {
+		return nil
+	}
+
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext($1))", "comment:"+ipAddress); err != nil {
+		return fmt.Errorf("댓글 작성 빈도 확인 실패: %v", err)
+	}
+
+	var count int
+	err := tx.QueryRow(`
+		SELECT COUNT(*) FROM comments
+		WHERE ip_address = $1 AND created_at > NOW() - make_interval(secs => $2)
+	`, ipAddress, settings.CommentRateWindow().Seconds()).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("댓글 작성 빈도 확인 실패: %v", err)
+	}
+	if count >= settings.CommentRateLimit {
+		return ErrCommentRateLimited
+	}
+	return nil
+}
+
+// DeleteComment 댓글 삭제 (소프트 삭제)
+func (s *CommentService) DeleteComment(id int) error {
+	return s.updateCommentStatus(id, "SET deleted_at = NOW()", "삭제", "deleted_at IS NULL")
+}
+
+// RestoreComment 댓글 복구
+func (s *CommentService) RestoreComment(id int) error {
+	return s.updateCommentStatus(id, "SET deleted_at = NULL", "복구", "deleted_at IS NOT NULL")
+}
+
+// updateCommentStatus 댓글 상태 업데이트 (삭제/복구 통합)
+func (s *CommentService) updateCommentStatus(id int, setClause, action, whereCondition string) error {
+	query := fmt.Sprintf("UPDATE comments %s WHERE id = $1 AND %s", setClause, whereCondition)
+
+	result, err := s.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("댓글 %s 실패: %v", action, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s 결과 확인 실패: %v", action, err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("댓글을 찾을 수 없거나 이미 %s되었습니다", action)
+	}
+
+	return nil
+}
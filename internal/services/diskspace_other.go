@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package services
+
+// diskFreeSpace 지원하지 않는 플랫폼에서는 확인하지 않음
+func diskFreeSpace(path string) (uint64, error) {
+	return 0, errDiskSpaceUnsupported
+}
@@ -0,0 +1,14 @@
+//go:build linux || darwin
+
+package services
+
+import "syscall"
+
+// diskFreeSpace 경로가 있는 파일 시스템에서 일반 사용자가 쓸 수 있는 여유 공간 (바이트)
+func diskFreeSpace(path string) (uint64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
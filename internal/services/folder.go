@@ -0,0 +1,136 @@
+package services
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"file-board/internal/models"
+)
+
+// 폴더 업로드 상대 경로 제한
+const (
+	maxRelativePathLength = 1024 // 전체 경로 길이 (바이트)
+	maxRelativePathDepth  = 32   // 폴더 깊이
+	maxPathSegmentLength  = 255  // 폴더/파일 이름 하나의 길이 (바이트)
+)
+
+// NormalizeRelativePath 업로드된 상대 경로 검증 및 정규화 (절대 경로, 드라이브 경로, 상위 경로 이동, 제어 문자 거부)
+func NormalizeRelativePath(relativePath string) (string, error) {
+	if relativePath == "" {
+		return "", fmt.Errorf("경로가 비어 있습니다")
+	}
+	if len(relativePath) > maxRelativePathLength || !utf8.ValidString(relativePath) {
+		return "", fmt.Errorf("잘못된 경로: %s", relativePath)
+	}
+	if strings.HasPrefix(relativePath, "/") || strings.Contains(relativePath, "\\") {
+		return "", fmt.Errorf("잘못된 경로: %s", relativePath)
+	}
+	if len(relativePath) >= 2 && relativePath[1] == ':' && isASCIILetter(relativePath[0]) {
+		// Windows 드라이브 경로 (C:/...)
+		return "", fmt.Errorf("잘못된 경로: %s", relativePath)
+	}
+
+	segments := strings.Split(relativePath, "/")
+	if len(segments) > maxRelativePathDepth {
+		return "", fmt.Errorf("폴더 깊이가 너무 깊습니다: %s", relativePath)
+	}
+	for _, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." || len(segment) > maxPathSegmentLength {
+			return "", fmt.Errorf("잘못된 경로: %s", relativePath)
+		}
+		if strings.IndexFunc(segment, func(r rune) bool { return r < 0x20 || r == 0x7f }) >= 0 {
+			return "", fmt.Errorf("잘못된 경로: %s", relativePath)
+		}
+	}
+	return relativePath, nil
+}
+
+// isASCIILetter 영문자인지 확인
+func isASCIILetter(b byte) bool {
+	return ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
+
+// NormalizeFolder 폴더 조회 경로 정규화 (빈 값은 최상위)
+func NormalizeFolder(dir string) (string, error) {
+	dir = strings.Trim(dir, "/")
+	if dir == "" {
+		return "", nil
+	}
+	return NormalizeRelativePath(dir)
+}
+
+// ListFolder 첨부 파일 목록에서 특정 폴더의 하위 폴더와 파일 목록 구성
+func ListFolder(attachments []models.Attachment, dir string) *models.FolderListing {
+	listing := &models.FolderListing{Path: dir}
+
+	// 상위 폴더 이동 경로
+	if dir != "" {
+		parts := strings.Split(dir, "/")
+		listing.Parent = strings.Join(parts[:len(parts)-1], "/")
+		for i := range parts {
+			listing.Breadcrumbs = append(listing.Breadcrumbs, models.FolderEntry{
+				Name: parts[i],
+				Path: strings.Join(parts[:i+1], "/"),
+			})
+		}
+	}
+
+	prefix := ""
+	if dir != "" {
+		prefix = dir + "/"
+	}
+
+	folders := make(map[string]*models.FolderEntry)
+	for _, attachment := range attachments {
+		name := attachment.DisplayPath()
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(name, prefix)
+
+		folderName, _, isNested := strings.Cut(rest, "/")
+		if !isNested {
+			listing.Files = append(listing.Files, attachment)
+			continue
+		}
+
+		folder, ok := folders[folderName]
+		if !ok {
+			folder = &models.FolderEntry{Name: folderName, Path: prefix + folderName}
+			folders[folderName] = folder
+		}
+		folder.FileCount++
+		if attachment.File != nil {
+			folder.Size += attachment.File.FileSize
+		}
+	}
+
+	for _, folder := range folders {
+		listing.Folders = append(listing.Folders, *folder)
+	}
+	sort.Slice(listing.Folders, func(i, j int) bool {
+		return listing.Folders[i].Name < listing.Folders[j].Name
+	})
+	sort.SliceStable(listing.Files, func(i, j int) bool {
+		return path.Base(listing.Files[i].DisplayPath()) < path.Base(listing.Files[j].DisplayPath())
+	})
+	return listing
+}
+
+// FilterFolder 특정 폴더(하위 폴더 포함)에 속한 첨부 파일만 선택
+func FilterFolder(attachments []models.Attachment, dir string) []models.Attachment {
+	if dir == "" {
+		return attachments
+	}
+
+	var filtered []models.Attachment
+	for _, attachment := range attachments {
+		if strings.HasPrefix(attachment.DisplayPath(), dir+"/") {
+			filtered = append(filtered, attachment)
+		}
+	}
+	return filtered
+}
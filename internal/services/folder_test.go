@@ -0,0 +1,161 @@
+package services
+
+import (
+	"mime/multipart"
+	"strings"
+	"testing"
+
+	"file-board/internal/models"
+)
+
+func TestNormalizeRelativePath(t *testing.T) {
+	tests := []struct {
+		input   string
+		wantErr bool
+	}{
+		{input: "photos/a.jpg"},
+		{input: "photos/2025/여름/a.jpg"},
+		{input: "a.jpg"},
+		{input: "photos/.hidden"},
+		{input: "photos/..a"},
+		{input: "photos/C:a.jpg"},
+		{input: "", wantErr: true},
+		{input: "../a.jpg", wantErr: true},
+		{input: "photos/../../a.jpg", wantErr: true},
+		{input: "photos/..", wantErr: true},
+		{input: "./photos/a.jpg", wantErr: true},
+		{input: "photos/./a.jpg", wantErr: true},
+		{input: "/etc/passwd", wantErr: true},
+		{input: "photos\\a.jpg", wantErr: true},
+		{input: "..\\..\\a.jpg", wantErr: true},
+		{input: "C:/Windows/a.jpg", wantErr: true},
+		{input: "c:a.jpg", wantErr: true},
+		{input: "photos//a.jpg", wantErr: true},
+		{input: "photos/", wantErr: true},
+		{input: "photos/a\x00.jpg", wantErr: true},
+		{input: "photos/a\n.jpg", wantErr: true},
+		{input: "photos/\xff.jpg", wantErr: true},
+		{input: strings.Repeat("a/", maxRelativePathDepth) + "a.jpg", wantErr: true},
+		{input: "photos/" + strings.Repeat("a", maxPathSegmentLength+1), wantErr: true},
+		{input: strings.Repeat(strings.Repeat("a", 250)+"/", 5) + "a.jpg", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := NormalizeRelativePath(tt.input)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("NormalizeRelativePath(%q) = %q, want error", tt.input, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NormalizeRelativePath(%q): %v", tt.input, err)
+			continue
+		}
+		if got != tt.input {
+			t.Errorf("NormalizeRelativePath(%q) = %q, want unchanged", tt.input, got)
+		}
+	}
+}
+
+func TestNormalizeFolder(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "", want: ""},
+		{input: "/", want: ""},
+		{input: "/photos/2025/", want: "photos/2025"},
+		{input: "photos/../..", wantErr: true},
+		{input: "photos\\2025", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := NormalizeFolder(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("NormalizeFolder(%q) = %q, %v, want %q (error %v)", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestValidateRelativePaths(t *testing.T) {
+	s := &PostService{}
+	files := func(n int) []*multipart.FileHeader {
+		return make([]*multipart.FileHeader, n)
+	}
+
+	got, err := s.validateRelativePaths(files(2), nil)
+	if err != nil || got != nil {
+		t.Errorf("validateRelativePaths(no paths) = %v, %v, want nil, nil", got, err)
+	}
+
+	got, err = s.validateRelativePaths(files(2), []string{"photos/a.jpg", "photos/sub/b.jpg"})
+	if err != nil {
+		t.Fatalf("validateRelativePaths: %v", err)
+	}
+	if len(got) != 2 || got[0] != "photos/a.jpg" || got[1] != "photos/sub/b.jpg" {
+		t.Errorf("validateRelativePaths = %v", got)
+	}
+
+	invalid := map[string][]string{
+		"count mismatch": {"photos/a.jpg"},
+		"duplicate":      {"photos/a.jpg", "photos/a.jpg"},
+		"parent":         {"photos/a.jpg", "../b.jpg"},
+		"backslash":      {"photos/a.jpg", "photos\\b.jpg"},
+		"absolute":       {"/photos/a.jpg", "photos/b.jpg"},
+		"drive":          {"photos/a.jpg", "D:/b.jpg"},
+		"empty segment":  {"photos//a.jpg", "photos/b.jpg"},
+		"empty path":     {"photos/a.jpg", ""},
+	}
+	for name, paths := range invalid {
+		if got, err := s.validateRelativePaths(files(2), paths); err == nil {
+			t.Errorf("%s: validateRelativePaths(%q) = %v, want error", name, paths, got)
+		}
+	}
+}
+
+func TestListFolder(t *testing.T) {
+	attachment := func(relativePath string, size int64) models.Attachment {
+		return models.Attachment{RelativePath: relativePath, File: &models.File{FileSize: size}}
+	}
+	attachments := []models.Attachment{
+		attachment("photos/b.jpg", 1),
+		attachment("photos/a.jpg", 2),
+		attachment("photos/2025/c.jpg", 4),
+		attachment("photos/2025/d/e.jpg", 8),
+		attachment("photos/2024/f.jpg", 16),
+		attachment("photos-old/g.jpg", 32),
+	}
+
+	listing := ListFolder(attachments, "photos")
+	if listing.Parent != "" || len(listing.Breadcrumbs) != 1 || listing.Breadcrumbs[0].Path != "photos" {
+		t.Errorf("parent = %q, breadcrumbs = %+v", listing.Parent, listing.Breadcrumbs)
+	}
+	if len(listing.Files) != 2 || listing.Files[0].RelativePath != "photos/a.jpg" || listing.Files[1].RelativePath != "photos/b.jpg" {
+		t.Errorf("files = %+v, want a.jpg, b.jpg", listing.Files)
+	}
+	want := []models.FolderEntry{
+		{Name: "2024", Path: "photos/2024", FileCount: 1, Size: 16},
+		{Name: "2025", Path: "photos/2025", FileCount: 2, Size: 12},
+	}
+	if len(listing.Folders) != len(want) {
+		t.Fatalf("folders = %+v, want %+v", listing.Folders, want)
+	}
+	for i := range want {
+		if listing.Folders[i] != want[i] {
+			t.Errorf("folder %d = %+v, want %+v", i, listing.Folders[i], want[i])
+		}
+	}
+
+	nested := ListFolder(attachments, "photos/2025/d")
+	if nested.Parent != "photos/2025" || len(nested.Breadcrumbs) != 3 || len(nested.Files) != 1 {
+		t.Errorf("nested listing = %+v", nested)
+	}
+
+	if filtered := FilterFolder(attachments, "photos/2025"); len(filtered) != 2 {
+		t.Errorf("FilterFolder(photos/2025) = %d attachments, want 2", len(filtered))
+	}
+	if filtered := FilterFolder(attachments, "photos"); len(filtered) != 5 {
+		t.Errorf("FilterFolder(photos) = %d attachments, want 5 (photos-old excluded)", len(filtered))
+	}
+}
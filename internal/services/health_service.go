@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"file-board/internal/config"
+	"file-board/internal/database"
+	"file-board/internal/models"
+)
+
+// errDiskSpaceUnsupported 여유 공간을 확인할 수 없는 플랫폼
+var errDiskSpaceUnsupported = errors.New("이 플랫폼에서는 여유 공간을 확인할 수 없습니다")
+
+type HealthService struct {
+	db  *database.DB
+	cfg *config.Config
+}
+
+func NewHealthService(db *database.DB, cfg *config.Config) *HealthService {
+	return &HealthService{db: db, cfg: cfg}
+}
+
+// healthCheck 확인 항목 (정상이면 확인한 값을 설명으로 반환)
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) (string, error)
+}
+
+// Ready 요청을 처리할 수 있는지 확인 (DB 연결, 업로드 디렉토리 쓰기, 여유 공간, 마이그레이션)
+func (s *HealthService) Ready(ctx context.Context) *models.HealthReport {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Health.CheckTimeout)
+	defer cancel()
+
+	checks := []healthCheck{
+		{"database", s.checkDatabase},
+		{"uploads_dir", s.checkUploadsWritable},
+		{"disk_space", s.checkDiskSpace},
+		{"migrations", s.checkMigrations},
+	}
+
+	report := &models.HealthReport{Status: models.HealthStatusOK}
+	for _, c := range checks {
+		start := time.Now()
+		detail, err := c.check(ctx)
+		result := models.HealthCheck{Name: c.name, OK: err == nil, Detail: detail, DurationMS: time.Since(start).Milliseconds()}
+		if err != nil {
+			result.Detail = err.Error()
+			report.Status = models.HealthStatusUnavailable
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	return report
+}
+
+// checkDatabase 데이터베이스 연결 확인
+func (s *HealthService) checkDatabase(ctx context.Context) (string, error) {
+	if err := s.db.Ping(ctx); err != nil {
+		return "", fmt.Errorf("데이터베이스 연결 실패: %v", err)
+	}
+	return "", nil
+}
+
+// checkUploadsWritable 업로드 디렉토리에 임시 파일을 만들어 쓰기 가능한지 확인
+func (s *HealthService) checkUploadsWritable(ctx context.Context) (string, error) {
+	dir := s.cfg.File.UploadsDir
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("업로드 디렉토리 생성 실패: %v", err)
+	}
+
+	file, err := os.CreateTemp(dir, ".healthcheck-*")
+	if err != nil {
+		return "", fmt.Errorf("업로드 디렉토리 쓰기 실패: %v", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString("ok"); err != nil {
+		file.Close()
+		return "", fmt.Errorf("업로드 디렉토리 쓰기 실패: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("업로드 디렉토리 쓰기 실패: %v", err)
+	}
+	return dir, nil
+}
+
+// checkDiskSpace 업로드 디렉토리 여유 공간이 설정값 이상인지 확인
+func (s *HealthService) checkDiskSpace(ctx context.Context) (string, error) {
+	free, err := diskFreeSpace(s.cfg.File.UploadsDir)
+	if errors.Is(err, errDiskSpaceUnsupported) {
+		return err.Error(), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("여유 공간 확인 실패: %v", err)
+	}
+
+	detail := fmt.Sprintf("여유 %d MB (최소 %d MB)", free/(1024*1024), s.cfg.Health.MinFreeSpace/(1024*1024))
+	if free < uint64(s.cfg.Health.MinFreeSpace) {
+		return "", fmt.Errorf("여유 공간 부족: %s", detail)
+	}
+	return detail, nil
+}
+
+// checkMigrations 내장된 마이그레이션이 모두 적용되었는지 확인
+func (s *HealthService) checkMigrations(ctx context.Context) (string, error) {
+	pending, err := s.db.PendingMigrations(ctx)
+	if err != nil {
+		return "", err
+	}
+	if len(pending) > 0 {
+		return "", fmt.Errorf("적용되지 않은 마이그레이션: %s", strings.Join(pending, ", "))
+	}
+	return "", nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"file-board/internal/models"
+)
+
+// postPinnedExpr 지금 목록 위에 고정되어 표시되는 게시글 조건 (공지는 게시 기간 안에서만)
+const postPinnedExpr = `(p.pin_order IS NOT NULL AND p.deleted_at IS NULL AND (NOT p.is_announcement OR (
+	(p.announce_from IS NULL OR p.announce_from <= NOW()) AND (p.announce_until IS NULL OR p.announce_until > NOW()))))`
+
+// postOrderClause 고정 글을 순서대로 먼저, 나머지는 최신순
+var postOrderClause = fmt.Sprintf("%s DESC, CASE WHEN %s THEN p.pin_order END, p.created_at DESC",
+	postPinnedExpr, postPinnedExpr)
+
+// PinPost 게시글 고정 및 공지 설정 (이미 고정된 게시글은 설정을 덮어씀)
+func (s *PostService) PinPost(ctx context.Context, id int, req models.PinRequest) error {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.WriteTimeout)
+	defer cancel()
+
+	if req.Order != nil && *req.Order < 0 {
+		return fmt.Errorf("고정 순서는 0 이상이어야 합니다")
+	}
+	// 게시 기간은 공지에만 적용
+	if !req.Announcement {
+		req.AnnounceFrom, req.AnnounceUntil = nil, nil
+	}
+	if req.AnnounceFrom != nil && req.AnnounceUntil != nil && !req.AnnounceUntil.After(*req.AnnounceFrom) {
+		return fmt.Errorf("공지 종료 시각은 시작 시각 이후여야 합니다")
+	}
+
+	// 순서를 지정하지 않으면 같은 게시판의 마지막 고정 글 다음에 배치
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE posts
+		SET pin_order = COALESCE($2, (
+		        SELECT COALESCE(MAX(q.pin_order) + 1, 0) FROM posts q
+		        WHERE q.board_id = posts.board_id AND q.pin_order IS NOT NULL AND q.id <> posts.id
+		    )),
+		    is_announcement = $3, announce_from = $4, announce_until = $5
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, req.Order, req.Announcement, req.AnnounceFrom, req.AnnounceUntil)
+	if err != nil {
+		return fmt.Errorf("게시글 고정 실패: %v", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("고정 결과 확인 실패: %v", err)
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// UnpinPost 게시글 고정 및 공지 해제
+func (s *PostService) UnpinPost(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.WriteTimeout)
+	defer cancel()
+
+	return s.updatePostStatus(ctx, id,
+		"SET pin_order = NULL, is_announcement = FALSE, announce_from = NULL, announce_until = NULL",
+		"고정 해제", "pin_order IS NOT NULL")
+}
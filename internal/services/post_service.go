@@ -1,61 +1,122 @@
 package services
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"hash/fnv"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"os"
+	"path"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
+	"file-board/internal/archive"
 	"file-board/internal/config"
+	"file-board/internal/jobs"
+	"file-board/internal/markdown"
+	"file-board/internal/metrics"
 	"file-board/internal/models"
+	"file-board/internal/scanner"
+	"file-board/internal/thumbnail"
+
+	"github.com/gabriel-vasile/mimetype"
+	"github.com/lib/pq"
+)
+
+var (
+	// ErrFileQuarantined 악성코드가 탐지되어 격리된 파일
+	ErrFileQuarantined = errors.New("악성코드가 탐지되어 격리된 파일입니다")
+	// ErrFileScanPending 악성코드 검사가 끝나지 않은 파일
+	ErrFileScanPending = errors.New("악성코드 검사가 진행 중인 파일입니다")
+	// ErrUploadsDisabled 관리자가 파일 업로드를 중지함
+	ErrUploadsDisabled = errors.New("현재 파일 업로드가 중지되어 있습니다")
+	// ErrUploadQuotaExceeded IP별 업로드 한도 초과
+	ErrUploadQuotaExceeded = errors.New("업로드 한도를 초과했습니다. 잠시 후 다시 시도해주세요")
+
+	// errAttachmentFileRemoved 중복 제거로 재사용하려던 files 항목이 게시글 저장 전에 정리됨
+	errAttachmentFileRemoved = errors.New("첨부할 파일 정보가 삭제되었습니다")
 )
 
+// 마크다운 렌더링 결과를 캐시할 게시글 수
+const markdownCacheSize = 1000
+
 type PostService struct {
-	db  *sql.DB
-	cfg *config.Config
+	db       *sql.DB
+	cfg      *config.Config
+	settings *SettingsService
+	scanner  *scanner.Scanner // nil이면 악성코드 검사 비활성화
+	jobs     *jobs.Queue
+	markdown *markdown.Cache
+	metrics  *metrics.Metrics // nil이면 지표 기록 안 함
 }
 
-func NewPostService(db *sql.DB, cfg *config.Config) *PostService {
-	return &PostService{db: db, cfg: cfg}
+func NewPostService(db *sql.DB, cfg *config.Config, settings *SettingsService, fileScanner *scanner.Scanner, jobQueue *jobs.Queue, m *metrics.Metrics) *PostService {
+	return &PostService{
+		db:       db,
+		cfg:      cfg,
+		settings: settings,
+		scanner:  fileScanner,
+		jobs:     jobQueue,
+		markdown: markdown.NewCache(markdownCacheSize),
+		metrics:  m,
+	}
 }
 
-// GetPosts 게시글 목록 조회 (일반 사용자용 - 삭제된 것 제외)
-func (s *PostService) GetPosts() ([]models.Post, error) {
-	return s.getPosts(false)
+// withTimeout 작업별 제한 시간 적용 (0이면 요청 컨텍스트의 취소만 따름)
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
 }
 
-// GetAllPosts 모든 게시글 조회 (관리자용 - 삭제된 것 포함)
-func (s *PostService) GetAllPosts() ([]models.Post, error) {
-	return s.getPosts(true)
+// GetPosts 게시판의 게시글 목록 조회 (일반 사용자용 - 삭제된 것 제외, tag가 있으면 해당 태그만)
+func (s *PostService) GetPosts(ctx context.Context, boardSlug, tag string) ([]models.Post, error) {
+	return s.getPosts(ctx, models.PostFilter{Board: boardSlug, Tag: tag, Status: "active"}, false)
 }
 
-// getPosts 통합 게시글 조회 메서드 (files 테이블 조인)
-func (s *PostService) getPosts(includeDeleted bool) ([]models.Post, error) {
-	whereClause := ""
-	if !includeDeleted {
-		whereClause = "WHERE p.deleted_at IS NULL"
+// GetAllPosts 모든 게시글 조회 (관리자용 - 삭제된 것 포함, 검색 조건 적용)
+func (s *PostService) GetAllPosts(ctx context.Context, filter models.PostFilter) ([]models.Post, error) {
+	return s.getPosts(ctx, filter, true)
+}
+
+// getPosts 통합 게시글 조회 메서드 (files, boards 테이블 조인)
+func (s *PostService) getPosts(ctx context.Context, filter models.PostFilter, includeDeleted bool) ([]models.Post, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.QueryTimeout)
+	defer cancel()
+
+	whereClause, args, err := postFilterClause(filter)
+	if err != nil {
+		return nil, err
 	}
 
 	query := fmt.Sprintf(`
 		SELECT p.id, p.title, COALESCE(p.content, '') as content, p.file_name, p.file_id, 
-		       p.post_type, p.created_at%s,
-		       f.id, f.file_hash, f.file_path, f.file_size, f.mime_type
+		       p.post_type, COALESCE(p.ip_address, ''), p.created_at%s,
+		       (SELECT COUNT(*) FROM comments c WHERE c.post_id = p.id AND c.deleted_at IS NULL),
+		       p.board_id, b.slug, b.name,
+		       p.pin_order, p.is_announcement, p.announce_from, p.announce_until, %s,
+		       f.id, f.file_hash, f.file_path, f.file_size, f.mime_type,
+		       f.scan_status, f.scan_signature, f.has_thumbnail
 		FROM posts p
+		JOIN boards b ON p.board_id = b.id
 		LEFT JOIN files f ON p.file_id = f.id
-		%s
-		ORDER BY p.created_at DESC
+		WHERE %s
+		ORDER BY %s
 	`, func() string {
 		if includeDeleted {
 			return ", p.deleted_at"
 		}
 		return ""
-	}(), whereClause)
+	}(), postPinnedExpr, whereClause, postOrderClause)
 
-	rows, err := s.db.Query(query)
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("게시글 조회 실패: %v", err)
 	}
@@ -65,12 +126,22 @@ func (s *PostService) getPosts(includeDeleted bool) ([]models.Post, error) {
 	for rows.Next() {
 		post, err := s.scanPost(rows, includeDeleted)
 		if err != nil {
-			fmt.Printf("게시글 스캔 에러 (ID 건너뜀): %v\n", err)
+			slog.WarnContext(ctx, "게시글 스캔 실패, 건너뜀", "error", err)
 			continue
 		}
+		if post.PostType == "message" && post.Content != "" {
+			post.ContentHTML = s.markdown.Render(post.ID, post.Content)
+		}
 		posts = append(posts, *post)
 	}
 
+	if err := s.loadAttachments(ctx, posts); err != nil {
+		return nil, err
+	}
+	if err := s.loadTags(ctx, posts); err != nil {
+		return nil, err
+	}
+
 	return posts, nil
 }
 
@@ -79,17 +150,21 @@ func (s *PostService) scanPost(rows *sql.Rows, includeDeleted bool) (*models.Pos
 	var post models.Post
 	var fileName sql.NullString
 	var fileID sql.NullInt32
-	
+
 	// files 테이블 필드들
 	var fID sql.NullInt32
-	var fHash, fPath, fMimeType sql.NullString
+	var fHash, fPath, fMimeType, fScanStatus, fScanSignature sql.NullString
 	var fSize sql.NullInt64
+	var fHasThumbnail sql.NullBool
 
 	if includeDeleted {
 		err := rows.Scan(
 			&post.ID, &post.Title, &post.Content, &fileName, &fileID,
-			&post.PostType, &post.CreatedAt, &post.DeletedAt,
+			&post.PostType, &post.IPAddress, &post.CreatedAt, &post.DeletedAt,
+			&post.CommentCount, &post.BoardID, &post.BoardSlug, &post.BoardName,
+			&post.PinOrder, &post.IsAnnouncement, &post.AnnounceFrom, &post.AnnounceUntil, &post.Pinned,
 			&fID, &fHash, &fPath, &fSize, &fMimeType,
+			&fScanStatus, &fScanSignature, &fHasThumbnail,
 		)
 		if err != nil {
 			return nil, err
@@ -97,8 +172,11 @@ func (s *PostService) scanPost(rows *sql.Rows, includeDeleted bool) (*models.Pos
 	} else {
 		err := rows.Scan(
 			&post.ID, &post.Title, &post.Content, &fileName, &fileID,
-			&post.PostType, &post.CreatedAt,
+			&post.PostType, &post.IPAddress, &post.CreatedAt,
+			&post.CommentCount, &post.BoardID, &post.BoardSlug, &post.BoardName,
+			&post.PinOrder, &post.IsAnnouncement, &post.AnnounceFrom, &post.AnnounceUntil, &post.Pinned,
 			&fID, &fHash, &fPath, &fSize, &fMimeType,
+			&fScanStatus, &fScanSignature, &fHasThumbnail,
 		)
 		if err != nil {
 			return nil, err
@@ -109,40 +187,188 @@ func (s *PostService) scanPost(rows *sql.Rows, includeDeleted bool) (*models.Pos
 	if fileName.Valid {
 		post.FileName = fileName.String
 	}
-	
+
 	// file_id 설정
 	post.FileID = fileID
 
 	// 파일 정보 설정 (files 테이블에서 조인된 데이터)
 	if fID.Valid && fPath.Valid {
 		post.File = &models.File{
-			ID:       int(fID.Int32),
-			FileHash: fHash.String,
-			FilePath: fPath.String,
-			FileSize: fSize.Int64,
-			MimeType: fMimeType.String,
+			ID:            int(fID.Int32),
+			FileHash:      fHash.String,
+			FilePath:      fPath.String,
+			FileSize:      fSize.Int64,
+			MimeType:      fMimeType.String,
+			ScanStatus:    fScanStatus.String,
+			ScanSignature: fScanSignature.String,
+			HasThumbnail:  fHasThumbnail.Bool,
 		}
 	}
 
 	return &post, nil
 }
 
-// GetFileInfo 파일 정보 조회 (files 테이블 조인)
-func (s *PostService) GetFileInfo(id int) (string, string, error) {
-	var fileName, filePath string
-	err := s.db.QueryRow(`
-		SELECT p.file_name, f.file_path 
-		FROM posts p 
-		JOIN files f ON p.file_id = f.id 
-		WHERE p.id = $1 AND p.post_type = 'file'
-	`, id).Scan(&fileName, &filePath)
-	
-	return fileName, filePath, err
+// GetPost 게시글 하나 조회 (삭제된 게시글 제외, 첨부 파일 포함)
+func (s *PostService) GetPost(ctx context.Context, id int) (*models.Post, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.QueryTimeout)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT p.id, p.title, COALESCE(p.content, '') as content, p.file_name, p.file_id,
+		       p.post_type, COALESCE(p.ip_address, ''), p.created_at,
+		       (SELECT COUNT(*) FROM comments c WHERE c.post_id = p.id AND c.deleted_at IS NULL),
+		       p.board_id, b.slug, b.name,
+		       p.pin_order, p.is_announcement, p.announce_from, p.announce_until, %s,
+		       f.id, f.file_hash, f.file_path, f.file_size, f.mime_type,
+		       f.scan_status, f.scan_signature, f.has_thumbnail
+		FROM posts p
+		JOIN boards b ON p.board_id = b.id
+		LEFT JOIN files f ON p.file_id = f.id
+		WHERE p.id = $1 AND p.deleted_at IS NULL
+	`, postPinnedExpr), id)
+	if err != nil {
+		return nil, fmt.Errorf("게시글 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("게시글 조회 실패: %v", err)
+		}
+		return nil, sql.ErrNoRows
+	}
+	post, err := s.scanPost(rows, false)
+	if err != nil {
+		return nil, fmt.Errorf("게시글 스캔 실패: %v", err)
+	}
+	if post.PostType == "message" && post.Content != "" {
+		post.ContentHTML = s.markdown.Render(post.ID, post.Content)
+	}
+
+	posts := []models.Post{*post}
+	if err := s.loadAttachments(ctx, posts); err != nil {
+		return nil, err
+	}
+	if err := s.loadArchiveEntries(ctx, posts[0].Attachments); err != nil {
+		return nil, err
+	}
+	if err := s.loadTags(ctx, posts); err != nil {
+		return nil, err
+	}
+	return &posts[0], nil
+}
+
+// GetFileInfo 첨부 파일 정보 조회 (position은 게시글 내 첨부 순서, 격리/검사 중인 파일은 에러 반환)
+//
+// includeDeleted가 false이면 삭제된 게시글의 파일은 sql.ErrNoRows로 처리한다 (관리자 서버만 true).
+func (s *PostService) GetFileInfo(ctx context.Context, id, position int, includeDeleted bool) (string, string, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.QueryTimeout)
+	defer cancel()
+
+	query := `
+		SELECT pf.file_name, f.file_path, f.scan_status
+		FROM posts p
+		JOIN post_files pf ON pf.post_id = p.id AND pf.position = $2
+		JOIN files f ON pf.file_id = f.id
+		WHERE p.id = $1 AND p.post_type = 'file'`
+	if !includeDeleted {
+		query += " AND p.deleted_at IS NULL"
+	}
+
+	var fileName, filePath, scanStatus string
+	err := s.db.QueryRowContext(ctx, query, id, position).Scan(&fileName, &filePath, &scanStatus)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch scanStatus {
+	case models.ScanStatusInfected:
+		return "", "", ErrFileQuarantined
+	case models.ScanStatusPending:
+		return "", "", ErrFileScanPending
+	}
+
+	return fileName, filePath, nil
+}
+
+// GetFilePost 파일 게시글과 첨부 파일 정보 조회 (미리보기용, 격리/검사 중인 파일은 에러 반환)
+//
+// includeDeleted가 false이면 삭제된 게시글은 sql.ErrNoRows로 처리한다 (관리자 서버만 true).
+func (s *PostService) GetFilePost(ctx context.Context, id, position int, includeDeleted bool) (*models.Post, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.QueryTimeout)
+	defer cancel()
+
+	query := `
+		SELECT p.id, COALESCE(p.title, ''), pf.file_name, p.post_type, p.created_at,
+		       f.id, f.file_hash, f.file_path, f.file_size, COALESCE(f.mime_type, ''),
+		       f.scan_status, f.has_thumbnail
+		FROM posts p
+		JOIN post_files pf ON pf.post_id = p.id AND pf.position = $2
+		JOIN files f ON pf.file_id = f.id
+		WHERE p.id = $1 AND p.post_type = 'file'`
+	if !includeDeleted {
+		query += " AND p.deleted_at IS NULL"
+	}
+
+	post := models.Post{File: &models.File{}}
+	err := s.db.QueryRowContext(ctx, query, id, position).Scan(
+		&post.ID, &post.Title, &post.FileName, &post.PostType, &post.CreatedAt,
+		&post.File.ID, &post.File.FileHash, &post.File.FilePath, &post.File.FileSize, &post.File.MimeType,
+		&post.File.ScanStatus, &post.File.HasThumbnail,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	switch post.File.ScanStatus {
+	case models.ScanStatusInfected:
+		return nil, ErrFileQuarantined
+	case models.ScanStatusPending:
+		return nil, ErrFileScanPending
+	}
+
+	return &post, nil
 }
 
-// CreateFilePost 파일 게시글 생성 - files 테이블 활용
-func (s *PostService) CreateFilePost(title string, file *multipart.FileHeader, ipAddress string) error {
-	if err := s.validateFile(file); err != nil {
+// CreateFilePost 파일 게시글 생성 - 여러 파일을 하나의 게시글에 첨부 (파일 실체는 files 테이블에서 중복 제거)
+//
+// paths가 있으면 폴더 업로드로 보고 각 파일의 상대 경로로 사용한다 (files와 같은 순서).
+// 게시글을 저장하지 못하면 이번 요청에서 새로 등록한 파일은 다시 삭제한다.
+func (s *PostService) CreateFilePost(ctx context.Context, board *models.Board, title string, tags []string, files []*multipart.FileHeader, paths []string, ipAddress string) (err error) {
+	if !board.AllowsPostType("file") {
+		return ErrBoardPostTypeNotAllowed
+	}
+	settings := s.settings.Current()
+	if !settings.UploadsEnabled {
+		return ErrUploadsDisabled
+	}
+
+	if len(files) == 0 {
+		return fmt.Errorf("업로드할 파일이 없습니다")
+	}
+	maxFiles := settings.MaxFilesPerPost
+	if len(paths) > 0 {
+		maxFiles = settings.MaxFolderFiles
+	}
+	if len(files) > maxFiles {
+		return fmt.Errorf("파일은 한 번에 %d개까지 업로드할 수 있습니다", maxFiles)
+	}
+	var totalSize int64
+	for _, file := range files {
+		if err := s.validateFile(file, board.EffectiveMaxFileSize(settings.MaxFileSize())); err != nil {
+			return fmt.Errorf("%s: %v", file.Filename, err)
+		}
+		if !settings.AcceptsFile(file.Filename) {
+			return fmt.Errorf("%s: 업로드할 수 없는 파일 형식입니다", file.Filename)
+		}
+		totalSize += file.Size
+	}
+	if err := s.checkUploadQuota(ctx, ipAddress, totalSize, settings.UploadQuota()); err != nil {
+		return err
+	}
+
+	relativePaths, err := s.validateRelativePaths(files, paths)
+	if err != nil {
 		return err
 	}
 
@@ -151,66 +377,376 @@ func (s *PostService) CreateFilePost(title string, file *multipart.FileHeader, i
 		return fmt.Errorf("업로드 디렉토리 생성 실패: %v", err)
 	}
 
+	// 이번 요청에서 새로 등록한 파일 (게시글에 연결되지 못하면 정리)
+	var createdFiles []int
+	defer func() {
+		if err != nil && !errors.Is(err, ErrFileQuarantined) && len(createdFiles) > 0 {
+			s.removeOrphanFiles(ctx, createdFiles)
+		}
+	}()
+
+	// 제목 설정
+	if title == "" {
+		title = files[0].Filename
+		if relativePaths != nil {
+			root, _, _ := strings.Cut(relativePaths[0], "/")
+			title = fmt.Sprintf("%s (%d개 파일)", root, len(files))
+		} else if len(files) > 1 {
+			title = fmt.Sprintf("%s 외 %d개", files[0].Filename, len(files)-1)
+		}
+	}
+
+	// 재사용하려던 files 항목이 저장 직전에 정리되었으면 한 번 더 등록 후 저장
+	var infected bool
+	for attempt := 0; ; attempt++ {
+		attachments := make([]models.Attachment, 0, len(files))
+		infected = false
+		for i, file := range files {
+			fileID, scanStatus, created, err := s.storeFile(ctx, file)
+			if created {
+				createdFiles = append(createdFiles, fileID)
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					// 클라이언트 연결 종료 또는 서버 종료로 취소됨 (복사 중이던 파일은 saveFile에서 삭제)
+					return ctx.Err()
+				}
+				return fmt.Errorf("%s: %v", file.Filename, err)
+			}
+			if scanStatus == models.ScanStatusInfected {
+				infected = true
+			}
+			attachment := models.Attachment{
+				Position: i,
+				FileName: file.Filename,
+				File:     &models.File{ID: fileID},
+			}
+			if relativePaths != nil {
+				attachment.RelativePath = relativePaths[i]
+				attachment.FileName = path.Base(relativePaths[i])
+			}
+			attachments = append(attachments, attachment)
+		}
+
+		err = s.savePostWithFiles(ctx, board.ID, title, tags, attachments, ipAddress)
+		if errors.Is(err, errAttachmentFileRemoved) && attempt == 0 {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		break
+	}
+
+	if infected {
+		return ErrFileQuarantined
+	}
+	return nil
+}
+
+// checkUploadQuota 최근 업로드 용량에 이번 업로드를 더해 IP별 한도를 넘는지 확인 (quota가 0이면 제한 없음)
+func (s *PostService) checkUploadQuota(ctx context.Context, ipAddress string, incoming, quota int64) error {
+	if quota <= 0 {
+		return nil
+	}
+	if incoming > quota {
+		return ErrUploadQuotaExceeded
+	}
+
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.QueryTimeout)
+	defer cancel()
+
+	var used int64
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(f.file_size), 0)
+		FROM posts p
+		JOIN post_files pf ON pf.post_id = p.id
+		JOIN files f ON f.id = pf.file_id
+		WHERE p.ip_address = $1 AND p.created_at > NOW() - make_interval(secs => $2)
+	`, ipAddress, models.UploadQuotaWindow.Seconds()).Scan(&used)
+	if err != nil {
+		return fmt.Errorf("업로드 한도 확인 실패: %v", err)
+	}
+	if used+incoming > quota {
+		return ErrUploadQuotaExceeded
+	}
+	return nil
+}
+
+// validateRelativePaths 폴더 업로드 상대 경로 검증 (경로가 없으면 nil, 파일 수와 맞지 않거나 중복되면 에러)
+func (s *PostService) validateRelativePaths(files []*multipart.FileHeader, paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+	if len(paths) != len(files) {
+		return nil, fmt.Errorf("파일 경로 정보가 올바르지 않습니다")
+	}
+
+	normalized := make([]string, len(paths))
+	seen := make(map[string]bool, len(paths))
+	for i, relativePath := range paths {
+		cleaned, err := NormalizeRelativePath(relativePath)
+		if err != nil {
+			return nil, err
+		}
+		if seen[cleaned] {
+			return nil, fmt.Errorf("같은 경로의 파일이 중복되었습니다: %s", cleaned)
+		}
+		seen[cleaned] = true
+		normalized[i] = cleaned
+	}
+	return normalized, nil
+}
+
+// storeFile 파일 실체 저장 및 files 테이블 등록 (이미 있는 내용이면 기존 항목 재사용)
+//
+// 파일 ID, 검사 상태와 함께 이번 호출에서 files 항목을 새로 만들었는지 반환한다.
+func (s *PostService) storeFile(ctx context.Context, file *multipart.FileHeader) (int, string, bool, error) {
 	// 파일 해시 생성
-	fileHash, err := s.generateFileHash(file)
+	fileHash, err := s.generateFileHash(ctx, file)
 	if err != nil {
-		return err
+		return 0, "", false, err
 	}
 
 	// files 테이블에서 중복 파일 확인
 	var fileID int
-	err = s.db.QueryRow(`
-		SELECT id FROM files WHERE file_hash = $1
-	`, fileHash).Scan(&fileID)
+	var filePath, scanStatus string
+	created := false
+	queryCtx, cancel := withTimeout(ctx, s.cfg.Database.QueryTimeout)
+	err = s.db.QueryRowContext(queryCtx, `
+		SELECT id, file_path, scan_status FROM files WHERE file_hash = $1
+	`, fileHash).Scan(&fileID, &filePath, &scanStatus)
+	cancel()
 
 	if err == sql.ErrNoRows {
 		// 새 파일 저장
-		filePath := filepath.Join(s.cfg.File.UploadsDir, fileHash)
-		
-		if err := s.saveFile(file, filePath); err != nil {
-			return err
+		filePath = filepath.Join(s.cfg.File.UploadsDir, fileHash)
+
+		if err := s.saveFile(ctx, file, filePath); err != nil {
+			return 0, "", false, err
+		}
+
+		scanStatus = models.ScanStatusUnscanned
+		if s.scanner != nil {
+			scanStatus = models.ScanStatusPending
 		}
 
-		// files 테이블에 저장
-		err = s.db.QueryRow(`
-			INSERT INTO files (file_hash, file_path, file_size, mime_type) 
-			VALUES ($1, $2, $3, $4) RETURNING id
-		`, fileHash, filePath, file.Size, file.Header.Get("Content-Type")).Scan(&fileID)
-		
+		// 실제 내용으로 MIME 타입 판별 (클라이언트가 보낸 Content-Type은 신뢰하지 않음)
+		mimeType := s.detectMimeType(filePath, file.Header.Get("Content-Type"))
+
+		// 압축 파일은 항목 색인 대기 상태로 등록
+		var archiveStatus sql.NullString
+		if archive.Detect(mimeType) != "" {
+			archiveStatus = sql.NullString{String: models.ArchiveStatusPending, Valid: true}
+		}
+
+		// files 테이블에 저장 (같은 내용을 동시에 올린 요청이 먼저 등록했으면 그 항목 사용)
+		writeCtx, cancel := withTimeout(ctx, s.cfg.Database.WriteTimeout)
+		err = s.db.QueryRowContext(writeCtx, `
+			INSERT INTO files (file_hash, file_path, file_size, mime_type, scan_status, archive_status)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (file_hash) DO NOTHING
+			RETURNING id
+		`, fileHash, filePath, file.Size, mimeType, scanStatus, archiveStatus).Scan(&fileID)
+		if err == sql.ErrNoRows {
+			err = s.db.QueryRowContext(writeCtx, `
+				SELECT id, file_path, scan_status FROM files WHERE file_hash = $1
+			`, fileHash).Scan(&fileID, &filePath, &scanStatus)
+			if err == nil {
+				cancel()
+				return s.reuseFile(ctx, file, fileID, filePath, scanStatus)
+			}
+		}
+		cancel()
+
 		if err != nil {
-			return fmt.Errorf("파일 정보 저장 실패: %v", err)
+			s.removeUnownedBlob(ctx, fileHash, filePath)
+			return 0, "", false, fmt.Errorf("파일 정보 저장 실패: %v", err)
+		}
+		created = true
+
+		s.metrics.ObserveUpload(file.Size, false)
+
+		// 파일이 등록된 뒤의 후속 작업 예약은 요청이 취소되어도 마침
+		ctx = context.WithoutCancel(ctx)
+
+		// 이미지 파일은 썸네일 생성 예약
+		if thumbnail.IsSupported(mimeType) {
+			s.requestThumbnail(ctx, fileID)
+		}
+		// 압축 파일은 항목 목록 색인 예약
+		if archiveStatus.Valid {
+			s.requestArchiveIndex(ctx, fileID)
 		}
 	} else if err != nil {
-		return fmt.Errorf("중복 파일 확인 실패: %v", err)
+		return 0, "", false, fmt.Errorf("중복 파일 확인 실패: %v", err)
+	} else {
+		// 기존 파일 ID 사용 (중복 파일)
+		return s.reuseFile(ctx, file, fileID, filePath, scanStatus)
 	}
-	// else: 기존 파일 ID 사용 (중복 파일)
 
-	// 제목 설정
-	if title == "" {
-		title = file.Filename
+	// 악성코드 검사 예약 (새 파일이거나 이전 검사가 완료되지 않은 파일)
+	ctx = context.WithoutCancel(ctx)
+	if s.scanner != nil && scanStatus != models.ScanStatusClean && scanStatus != models.ScanStatusInfected {
+		scanStatus = s.requestScan(ctx, fileID, filePath)
+	}
+
+	return fileID, scanStatus, created, nil
+}
+
+// reuseFile 이미 등록된 같은 내용의 files 항목 사용 (검사가 끝나지 않았으면 다시 예약)
+func (s *PostService) reuseFile(ctx context.Context, file *multipart.FileHeader, fileID int, filePath, scanStatus string) (int, string, bool, error) {
+	s.metrics.ObserveUpload(file.Size, true)
+
+	ctx = context.WithoutCancel(ctx)
+	if s.scanner != nil && scanStatus != models.ScanStatusClean && scanStatus != models.ScanStatusInfected {
+		scanStatus = s.requestScan(ctx, fileID, filePath)
+	}
+	return fileID, scanStatus, false, nil
+}
+
+// removeUnownedBlob files 등록에 실패한 파일 실체 삭제 (같은 해시의 항목이 있으면 그 항목의 실체이므로 유지)
+func (s *PostService) removeUnownedBlob(ctx context.Context, fileHash, filePath string) {
+	ctx, cancel := withTimeout(context.WithoutCancel(ctx), s.cfg.Database.QueryTimeout)
+	defer cancel()
+
+	var owned bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM files WHERE file_hash = $1)
+	`, fileHash).Scan(&owned)
+	if err != nil {
+		// 확인할 수 없으면 다른 항목의 실체일 수 있으므로 남겨 둠
+		slog.ErrorContext(ctx, "파일 실체 소유 확인 실패", "file_hash", fileHash, "error", err)
+		return
+	}
+	if owned {
+		return
+	}
+	if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+		slog.ErrorContext(ctx, "업로드 실패 파일 삭제 실패", "path", filePath, "error", err)
+	}
+}
+
+// removeOrphanFiles 게시글에 연결되지 못한 파일의 files 항목과 실체 삭제 (그 사이 다른 게시글이 참조하면 유지)
+func (s *PostService) removeOrphanFiles(ctx context.Context, fileIDs []int) {
+	// 요청이 취소되어 실패한 경우에도 정리는 마침
+	ctx, cancel := withTimeout(context.WithoutCancel(ctx), s.cfg.Database.WriteTimeout)
+	defer cancel()
+
+	for _, fileID := range fileIDs {
+		fileHash, filePath, err := s.deleteOrphanFile(ctx, fileID)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			slog.ErrorContext(ctx, "업로드 실패 파일 정리 실패", "file_id", fileID, "error", err)
+			continue
+		}
+
+		for _, p := range []string{filePath, s.thumbnailPath(fileHash)} {
+			if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+				slog.ErrorContext(ctx, "업로드 실패 파일 삭제 실패", "file_id", fileID, "path", p, "error", err)
+			}
+		}
+		slog.InfoContext(ctx, "게시글에 연결되지 않은 업로드 파일 정리", "file_id", fileID)
+	}
+}
+
+// deleteOrphanFile 참조가 없는 files 항목 삭제 (참조가 있거나 이미 없으면 sql.ErrNoRows)
+//
+// 중복 업로드가 같은 항목을 게시글에 연결하는 중이면 행 잠금을 기다린 뒤 새 스냅샷으로 참조를 다시 확인한다.
+func (s *PostService) deleteOrphanFile(ctx context.Context, fileID int) (string, string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	var fileHash, filePath string
+	err = tx.QueryRowContext(ctx, `
+		SELECT file_hash, file_path FROM files WHERE id = $1 FOR UPDATE
+	`, fileID).Scan(&fileHash, &filePath)
+	if err != nil {
+		return "", "", err
+	}
+
+	var referenced bool
+	err = tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM post_files WHERE file_id = $1)
+		    OR EXISTS (SELECT 1 FROM posts WHERE file_id = $1)
+	`, fileID).Scan(&referenced)
+	if err != nil {
+		return "", "", fmt.Errorf("파일 참조 확인 실패: %v", err)
+	}
+	if referenced {
+		return "", "", sql.ErrNoRows
 	}
 
-	// posts 테이블에 저장 (file_id 참조)
-	return s.savePostToDb(title, file.Filename, fileID, "file", ipAddress)
+	if _, err := tx.ExecContext(ctx, "DELETE FROM files WHERE id = $1", fileID); err != nil {
+		return "", "", fmt.Errorf("파일 정보 삭제 실패: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return "", "", fmt.Errorf("파일 정보 삭제 실패: %v", err)
+	}
+	return fileHash, filePath, nil
 }
 
 // CreateMessagePost 메시지 게시글 생성
-func (s *PostService) CreateMessagePost(title, content, ipAddress string) error {
-	return s.savePostToDb(title, "", 0, "message", ipAddress, content)
+func (s *PostService) CreateMessagePost(ctx context.Context, board *models.Board, title, content string, tags []string, ipAddress string) error {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.WriteTimeout)
+	defer cancel()
+
+	if !board.AllowsPostType("message") {
+		return ErrBoardPostTypeNotAllowed
+	}
+	return s.savePostToDb(ctx, board.ID, title, content, tags, ipAddress)
 }
 
 // DeletePost 게시글 삭제 (소프트 삭제)
-func (s *PostService) DeletePost(id int) error {
-	return s.updatePostStatus(id, "SET deleted_at = NOW()", "삭제", "deleted_at IS NULL")
+func (s *PostService) DeletePost(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.WriteTimeout)
+	defer cancel()
+
+	return s.updatePostStatus(ctx, id, "SET deleted_at = NOW()", "삭제", "deleted_at IS NULL")
 }
 
 // RestorePost 게시글 복구
-func (s *PostService) RestorePost(id int) error {
-	return s.updatePostStatus(id, "SET deleted_at = NULL", "복구", "deleted_at IS NOT NULL")
+func (s *PostService) RestorePost(ctx context.Context, id int) error {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.WriteTimeout)
+	defer cancel()
+
+	return s.updatePostStatus(ctx, id, "SET deleted_at = NULL", "복구", "deleted_at IS NOT NULL")
+}
+
+// DeletePostsByCIDR 특정 IP/CIDR 대역에서 작성된 게시글 일괄 삭제 (소프트 삭제)
+func (s *PostService) DeletePostsByCIDR(ctx context.Context, cidr string) (int64, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.BulkTimeout)
+	defer cancel()
+
+	normalized, err := NormalizeCIDR(cidr)
+	if err != nil {
+		return 0, err
+	}
+
+	// 주소로 해석되지 않는 값은 try_inet이 NULL을 반환하므로 캐스팅 오류 없이 제외됨
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE posts SET deleted_at = NOW()
+		WHERE deleted_at IS NULL
+		  AND try_inet(ip_address) IS NOT NULL
+		  AND try_inet(ip_address) <<= $1::cidr
+	`, normalized)
+	if err != nil {
+		return 0, fmt.Errorf("게시글 일괄 삭제 실패: %v", err)
+	}
+
+	return result.RowsAffected()
 }
 
 // GetStats 통계 조회 (files 테이블 포함)
-func (s *PostService) GetStats() (map[string]interface{}, error) {
+func (s *PostService) GetStats(ctx context.Context) (map[string]interface{}, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.BulkTimeout)
+	defer cancel()
+
 	stats := make(map[string]interface{})
 
 	queries := map[string]string{
@@ -224,7 +760,7 @@ func (s *PostService) GetStats() (map[string]interface{}, error) {
 
 	for key, query := range queries {
 		var count int
-		if err := s.db.QueryRow(query).Scan(&count); err != nil {
+		if err := s.db.QueryRowContext(ctx, query).Scan(&count); err != nil {
 			return nil, fmt.Errorf("%s 조회 실패: %v", key, err)
 		}
 		stats[key] = count
@@ -232,11 +768,11 @@ func (s *PostService) GetStats() (map[string]interface{}, error) {
 
 	// 총 파일 크기 계산 (files 테이블에서)
 	var totalSize sql.NullInt64
-	err := s.db.QueryRow("SELECT SUM(file_size) FROM files").Scan(&totalSize)
+	err := s.db.QueryRowContext(ctx, "SELECT SUM(file_size) FROM files").Scan(&totalSize)
 	if err != nil {
 		return nil, fmt.Errorf("파일 크기 합계 조회 실패: %v", err)
 	}
-	
+
 	if totalSize.Valid {
 		stats["total_storage_bytes"] = totalSize.Int64
 		stats["total_storage_mb"] = float64(totalSize.Int64) / (1024 * 1024)
@@ -248,18 +784,35 @@ func (s *PostService) GetStats() (map[string]interface{}, error) {
 	return stats, nil
 }
 
+// GetStorageUsage 저장소 사용량 조회 (실제 저장된 파일과 게시글이 참조하는 파일 크기 합계)
+func (s *PostService) GetStorageUsage(ctx context.Context) (*models.StorageUsage, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.QueryTimeout)
+	defer cancel()
+
+	var usage models.StorageUsage
+	err := s.db.QueryRowContext(ctx, `
+		SELECT (SELECT COUNT(*) FROM files),
+		       (SELECT COALESCE(SUM(file_size), 0) FROM files),
+		       (SELECT COALESCE(SUM(f.file_size), 0) FROM post_files pf JOIN files f ON pf.file_id = f.id)
+	`).Scan(&usage.Files, &usage.Bytes, &usage.ReferencedBytes)
+	if err != nil {
+		return nil, fmt.Errorf("저장소 사용량 조회 실패: %v", err)
+	}
+	return &usage, nil
+}
+
 // === 헬퍼 메서드들 ===
 
-// validateFile 파일 유효성 검사
-func (s *PostService) validateFile(file *multipart.FileHeader) error {
-	if file.Size > s.cfg.File.MaxFileSize {
-		return fmt.Errorf("파일 크기는 %s를 초과할 수 없습니다", s.cfg.GetMaxFileSizeText())
+// validateFile 파일 유효성 검사 (maxSize는 게시판에 적용되는 최대 크기)
+func (s *PostService) validateFile(file *multipart.FileHeader, maxSize int64) error {
+	if file.Size > maxSize {
+		return fmt.Errorf("파일 크기는 %dMB를 초과할 수 없습니다", maxSize/(1024*1024))
 	}
 	return nil
 }
 
 // generateFileHash FNV 해시 생성 (파일 내용 기반 - 중복 파일 감지용)
-func (s *PostService) generateFileHash(file *multipart.FileHeader) (string, error) {
+func (s *PostService) generateFileHash(ctx context.Context, file *multipart.FileHeader) (string, error) {
 	src, err := file.Open()
 	if err != nil {
 		return "", fmt.Errorf("파일 열기 실패: %v", err)
@@ -267,15 +820,29 @@ func (s *PostService) generateFileHash(file *multipart.FileHeader) (string, erro
 	defer src.Close()
 
 	hasher := fnv.New64a()
-	if _, err := io.Copy(hasher, src); err != nil {
+	if _, err := io.Copy(hasher, contextReader{ctx, src}); err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
 		return "", fmt.Errorf("파일 해시 생성 실패: %v", err)
 	}
 
 	return strconv.FormatUint(hasher.Sum64(), 16), nil
 }
 
-// saveFile 파일을 디스크에 저장
-func (s *PostService) saveFile(file *multipart.FileHeader, filePath string) error {
+// detectMimeType 저장된 파일 내용으로 MIME 타입 판별 (실패 시 요청 헤더 값 사용)
+func (s *PostService) detectMimeType(filePath, fallback string) string {
+	mtype, err := mimetype.DetectFile(filePath)
+	if err != nil {
+		return fallback
+	}
+	// 파라미터(charset 등) 제외한 기본 타입만 저장
+	mimeType, _, _ := strings.Cut(mtype.String(), ";")
+	return mimeType
+}
+
+// saveFile 파일을 디스크에 저장 (임시 파일에 복사한 뒤 이름을 바꾸므로 중단되어도 불완전한 파일이 남지 않음)
+func (s *PostService) saveFile(ctx context.Context, file *multipart.FileHeader, filePath string) error {
 	// 중복 파일 확인
 	if _, err := os.Stat(filePath); err == nil {
 		// 파일이 이미 존재하면 저장하지 않음 (중복 제거)
@@ -288,55 +855,191 @@ func (s *PostService) saveFile(file *multipart.FileHeader, filePath string) erro
 	}
 	defer src.Close()
 
-	dst, err := os.Create(filePath)
+	dst, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+".*.part")
 	if err != nil {
 		return fmt.Errorf("파일 생성 실패: %v", err)
 	}
-	defer dst.Close()
+	tempPath := dst.Name()
 
-	if _, err = io.Copy(dst, src); err != nil {
+	err = dst.Chmod(0644)
+	if err == nil {
+		_, err = io.Copy(dst, contextReader{ctx, src})
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tempPath)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		return fmt.Errorf("파일 복사 실패: %v", err)
 	}
 
+	if err := os.Rename(tempPath, filePath); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("파일 저장 실패: %v", err)
+	}
 	return nil
 }
 
-// savePostToDb 게시글을 데이터베이스에 저장 (files 테이블 구조용)
-func (s *PostService) savePostToDb(title, fileName string, fileID int, postType, ipAddress string, content ...string) error {
-	var contentStr string
-	if len(content) > 0 {
-		contentStr = content[0]
+// contextReader ctx가 취소되면 읽기를 중단하는 Reader (대용량 파일 복사 중단용)
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
 	}
+	return r.r.Read(p)
+}
 
-	var query string
-	var args []interface{}
+// savePostToDb 메시지 게시글과 태그를 한 트랜잭션으로 저장
+func (s *PostService) savePostToDb(ctx context.Context, boardID int, title, content string, tags []string, ipAddress string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
 
-	if postType == "file" {
-		query = `
-			INSERT INTO posts (title, content, file_name, file_id, post_type, ip_address) 
-			VALUES ($1, $2, $3, $4, $5, $6)
-		`
-		args = []interface{}{title, contentStr, fileName, fileID, postType, ipAddress}
-	} else {
-		query = `
-			INSERT INTO posts (title, content, post_type, ip_address) 
-			VALUES ($1, $2, $3, $4)
-		`
-		args = []interface{}{title, contentStr, postType, ipAddress}
+	var postID int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO posts (board_id, title, content, post_type, ip_address)
+		VALUES ($1, $2, $3, 'message', $4) RETURNING id
+	`, boardID, title, content, ipAddress).Scan(&postID)
+	if err != nil {
+		return fmt.Errorf("데이터베이스 저장 실패: %v", err)
+	}
+
+	if err := saveTags(ctx, tx, postID, tags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("데이터베이스 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// savePostWithFiles 파일 게시글과 첨부 파일 목록을 한 트랜잭션으로 저장 (첫 번째 파일을 대표 파일로 기록)
+func (s *PostService) savePostWithFiles(ctx context.Context, boardID int, title string, tags []string, attachments []models.Attachment, ipAddress string) error {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.WriteTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %v", err)
 	}
+	defer tx.Rollback()
 
-	_, err := s.db.Exec(query, args...)
+	// 첨부할 files 항목을 잠가 업로드 실패 정리가 그 사이 삭제하지 못하게 함
+	fileIDs := make([]int64, len(attachments))
+	for i, attachment := range attachments {
+		fileIDs[i] = int64(attachment.File.ID)
+	}
+	var locked int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM (SELECT id FROM files WHERE id = ANY($1) FOR SHARE) f
+	`, pq.Array(fileIDs)).Scan(&locked)
 	if err != nil {
+		return fmt.Errorf("첨부 파일 확인 실패: %v", err)
+	}
+	if locked != countDistinct(fileIDs) {
+		return errAttachmentFileRemoved
+	}
+
+	var postID int
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO posts (board_id, title, content, file_name, file_id, post_type, ip_address)
+		VALUES ($1, $2, '', $3, $4, 'file', $5) RETURNING id
+	`, boardID, title, attachments[0].FileName, attachments[0].File.ID, ipAddress).Scan(&postID)
+	if err != nil {
+		return fmt.Errorf("데이터베이스 저장 실패: %v", err)
+	}
+
+	for _, attachment := range attachments {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO post_files (post_id, file_id, file_name, position, relative_path)
+			VALUES ($1, $2, $3, $4, NULLIF($5, ''))
+		`, postID, attachment.File.ID, attachment.FileName, attachment.Position, attachment.RelativePath)
+		if err != nil {
+			return fmt.Errorf("첨부 파일 저장 실패: %v", err)
+		}
+	}
+
+	if err := saveTags(ctx, tx, postID, tags); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("데이터베이스 저장 실패: %v", err)
 	}
 	return nil
 }
 
+// countDistinct 중복을 제외한 ID 개수
+func countDistinct(ids []int64) int {
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	return len(seen)
+}
+
+// loadAttachments 파일 게시글들의 첨부 파일 목록을 한 번에 조회하여 채움
+func (s *PostService) loadAttachments(ctx context.Context, posts []models.Post) error {
+	index := make(map[int]*models.Post)
+	var ids []int64
+	for i := range posts {
+		if posts[i].PostType == "file" {
+			index[posts[i].ID] = &posts[i]
+			ids = append(ids, int64(posts[i].ID))
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT pf.id, pf.post_id, pf.position, pf.file_name, COALESCE(pf.relative_path, ''),
+		       f.id, f.file_hash, f.file_path, f.file_size, COALESCE(f.mime_type, ''),
+		       f.scan_status, COALESCE(f.scan_signature, ''), f.has_thumbnail,
+		       COALESCE(f.archive_status, ''), COALESCE(f.archive_error, '')
+		FROM post_files pf
+		JOIN files f ON pf.file_id = f.id
+		WHERE pf.post_id = ANY($1)
+		ORDER BY pf.post_id, pf.position
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("첨부 파일 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		attachment := models.Attachment{File: &models.File{}}
+		if err := rows.Scan(
+			&attachment.ID, &attachment.PostID, &attachment.Position, &attachment.FileName, &attachment.RelativePath,
+			&attachment.File.ID, &attachment.File.FileHash, &attachment.File.FilePath,
+			&attachment.File.FileSize, &attachment.File.MimeType,
+			&attachment.File.ScanStatus, &attachment.File.ScanSignature, &attachment.File.HasThumbnail,
+			&attachment.File.ArchiveStatus, &attachment.File.ArchiveError,
+		); err != nil {
+			return fmt.Errorf("첨부 파일 스캔 실패: %v", err)
+		}
+		if post, ok := index[attachment.PostID]; ok {
+			post.Attachments = append(post.Attachments, attachment)
+		}
+	}
+	return rows.Err()
+}
+
 // updatePostStatus 게시글 상태 업데이트 (삭제/복구 통합)
-func (s *PostService) updatePostStatus(id int, setClause, action, whereCondition string) error {
+func (s *PostService) updatePostStatus(ctx context.Context, id int, setClause, action, whereCondition string) error {
 	query := fmt.Sprintf("UPDATE posts %s WHERE id = $1 AND %s", setClause, whereCondition)
-	
-	result, err := s.db.Exec(query, id)
+
+	result, err := s.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return fmt.Errorf("게시글 %s 실패: %v", action, err)
 	}
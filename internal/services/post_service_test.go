@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"file-board/internal/config"
+	"file-board/internal/models"
+)
+
+// insertFilePost 첨부 파일 하나가 있는 파일 게시글 추가 후 게시글 ID 반환
+func insertFilePost(t *testing.T, db *sql.DB, fileID int, fileName string) int {
+	t.Helper()
+	postID := insertPost(t, db, "file", "203.0.113.7", sql.NullInt64{Int64: int64(fileID), Valid: true})
+	if _, err := db.Exec(`INSERT INTO post_files (post_id, file_id, file_name) VALUES ($1, $2, $3)`, postID, fileID, fileName); err != nil {
+		t.Fatalf("insert post file: %v", err)
+	}
+	return postID
+}
+
+func TestFileReadsHideDeletedPosts(t *testing.T) {
+	db := openTestDB(t)
+	s := &PostService{db: db, cfg: &config.Config{}}
+	ctx := context.Background()
+
+	fileID := insertFile(t, db, "00000000000000b1", "b1.bin", models.ScanStatusClean)
+	liveID := insertFilePost(t, db, fileID, "live.txt")
+	deletedID := insertFilePost(t, db, fileID, "deleted.txt")
+	if _, err := db.Exec("UPDATE posts SET deleted_at = NOW() WHERE id = $1", deletedID); err != nil {
+		t.Fatalf("delete post: %v", err)
+	}
+
+	if name, _, err := s.GetFileInfo(ctx, liveID, 0, false); err != nil || name != "live.txt" {
+		t.Errorf("GetFileInfo(live) = %q, %v", name, err)
+	}
+	if _, _, err := s.GetFileInfo(ctx, deletedID, 0, false); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetFileInfo(deleted) error = %v, want sql.ErrNoRows", err)
+	}
+	if name, _, err := s.GetFileInfo(ctx, deletedID, 0, true); err != nil || name != "deleted.txt" {
+		t.Errorf("GetFileInfo(deleted, includeDeleted) = %q, %v", name, err)
+	}
+
+	if post, err := s.GetFilePost(ctx, liveID, 0, false); err != nil || post.FileName != "live.txt" {
+		t.Errorf("GetFilePost(live) = %+v, %v", post, err)
+	}
+	if _, err := s.GetFilePost(ctx, deletedID, 0, false); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetFilePost(deleted) error = %v, want sql.ErrNoRows", err)
+	}
+	if post, err := s.GetFilePost(ctx, deletedID, 0, true); err != nil || post.FileName != "deleted.txt" {
+		t.Errorf("GetFilePost(deleted, includeDeleted) = %+v, %v", post, err)
+	}
+}
+
+func TestFileReadsRejectUnscannedStates(t *testing.T) {
+	db := openTestDB(t)
+	s := &PostService{db: db, cfg: &config.Config{}}
+	ctx := context.Background()
+
+	infectedID := insertFilePost(t, db, insertFile(t, db, "00000000000000b2", "b2.bin", models.ScanStatusInfected), "infected.exe")
+	pendingID := insertFilePost(t, db, insertFile(t, db, "00000000000000b3", "b3.bin", models.ScanStatusPending), "pending.zip")
+
+	if _, _, err := s.GetFileInfo(ctx, infectedID, 0, true); !errors.Is(err, ErrFileQuarantined) {
+		t.Errorf("GetFileInfo(infected) error = %v, want ErrFileQuarantined", err)
+	}
+	if _, err := s.GetFilePost(ctx, pendingID, 0, true); !errors.Is(err, ErrFileScanPending) {
+		t.Errorf("GetFilePost(pending) error = %v, want ErrFileScanPending", err)
+	}
+}
+
+func TestRemoveOrphanFiles(t *testing.T) {
+	db := openTestDB(t)
+	dir := t.TempDir()
+	s := &PostService{db: db, cfg: &config.Config{}}
+	s.cfg.File.UploadsDir = dir
+
+	orphanPath := filepath.Join(dir, "00000000000000e1")
+	if err := os.WriteFile(orphanPath, []byte("orphan"), 0644); err != nil {
+		t.Fatalf("write blob: %v", err)
+	}
+	orphanID := insertFile(t, db, "00000000000000e1", orphanPath, models.ScanStatusClean)
+	usedID := insertFile(t, db, "00000000000000e2", filepath.Join(dir, "00000000000000e2"), models.ScanStatusClean)
+	insertFilePost(t, db, usedID, "used.txt")
+
+	s.removeOrphanFiles(context.Background(), []int{orphanID, usedID})
+
+	var remaining []int
+	rows, err := db.Query("SELECT id FROM files ORDER BY id")
+	if err != nil {
+		t.Fatalf("query files: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		remaining = append(remaining, id)
+	}
+	if len(remaining) != 1 || remaining[0] != usedID {
+		t.Errorf("remaining files = %v, want [%d]", remaining, usedID)
+	}
+	if _, err := os.Stat(orphanPath); !os.IsNotExist(err) {
+		t.Errorf("orphan blob still exists: %v", err)
+	}
+}
+
+func TestSavePostWithFilesRemovedFile(t *testing.T) {
+	db := openTestDB(t)
+	s := &PostService{db: db, cfg: &config.Config{}}
+	ctx := context.Background()
+
+	var boardID int
+	if err := db.QueryRow("SELECT id FROM boards ORDER BY id LIMIT 1").Scan(&boardID); err != nil {
+		t.Fatalf("select board: %v", err)
+	}
+	fileID := insertFile(t, db, "00000000000000e3", "e3.bin", models.ScanStatusClean)
+	attachments := []models.Attachment{
+		{Position: 0, FileName: "a.txt", File: &models.File{ID: fileID}},
+		{Position: 1, FileName: "b.txt", File: &models.File{ID: fileID}},
+	}
+	if err := s.savePostWithFiles(ctx, boardID, "same file twice", nil, attachments, "203.0.113.7"); err != nil {
+		t.Fatalf("savePostWithFiles: %v", err)
+	}
+
+	removed := []models.Attachment{{Position: 0, FileName: "gone.txt", File: &models.File{ID: fileID + 1000}}}
+	if err := s.savePostWithFiles(ctx, boardID, "gone", nil, removed, "203.0.113.7"); !errors.Is(err, errAttachmentFileRemoved) {
+		t.Errorf("savePostWithFiles(removed file) error = %v, want errAttachmentFileRemoved", err)
+	}
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// RetentionInterval 보관 기간이 지난 게시글을 확인하는 주기
+const RetentionInterval = time.Hour
+
+// DeleteExpiredPosts 보관 기간이 지난 게시글 삭제 (소프트 삭제, 고정 글 제외, 보관 기간이 0이면 아무것도 하지 않음)
+//
+// 첨부 파일은 사용자 서버의 조회/다운로드/썸네일 경로에서 삭제된 게시글과 함께 가려지며,
+// 파일 실체와 files 항목은 관리자가 복원할 수 있도록 지우지 않는다.
+func (s *PostService) DeleteExpiredPosts(ctx context.Context) (int64, error) {
+	days := s.settings.Current().RetentionDays
+	if days <= 0 {
+		return 0, nil
+	}
+
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.BulkTimeout)
+	defer cancel()
+
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE posts SET deleted_at = NOW()
+		WHERE deleted_at IS NULL AND pin_order IS NULL
+		  AND created_at < NOW() - make_interval(days => $1)
+	`, days)
+	if err != nil {
+		return 0, fmt.Errorf("보관 기간 지난 게시글 삭제 실패: %v", err)
+	}
+	return result.RowsAffected()
+}
+
+// RunRetention ctx가 취소될 때까지 주기적으로 보관 기간이 지난 게시글 삭제
+func (s *PostService) RunRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.DeleteExpiredPosts(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "보관 기간 정리 실패", "error", err)
+		} else if deleted > 0 {
+			slog.InfoContext(ctx, "보관 기간이 지난 게시글 삭제", "posts", deleted, "retention_days", s.settings.Current().RetentionDays)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
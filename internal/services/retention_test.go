@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"file-board/internal/config"
+	"file-board/internal/models"
+)
+
+func TestDeleteExpiredPostsHidesFiles(t *testing.T) {
+	db := openTestDB(t)
+	settings := newSettingsTestService()
+	current := *settings.Current()
+	current.RetentionDays = 30
+	settings.current.Store(&current)
+	s := &PostService{db: db, cfg: &config.Config{}, settings: settings}
+	ctx := context.Background()
+
+	fileID := insertFile(t, db, "00000000000000f1", "f1.bin", models.ScanStatusClean)
+	expiredID := insertFilePost(t, db, fileID, "old.txt")
+	pinnedID := insertFilePost(t, db, insertFile(t, db, "00000000000000f2", "f2.bin", models.ScanStatusClean), "pinned.txt")
+	freshID := insertFilePost(t, db, insertFile(t, db, "00000000000000f3", "f3.bin", models.ScanStatusClean), "new.txt")
+	if _, err := db.Exec("UPDATE posts SET created_at = NOW() - INTERVAL '31 days' WHERE id IN ($1, $2)", expiredID, pinnedID); err != nil {
+		t.Fatalf("age posts: %v", err)
+	}
+	if _, err := db.Exec("UPDATE posts SET pin_order = 1 WHERE id = $1", pinnedID); err != nil {
+		t.Fatalf("pin post: %v", err)
+	}
+
+	deleted, err := s.DeleteExpiredPosts(ctx)
+	if err != nil {
+		t.Fatalf("DeleteExpiredPosts: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("deleted = %d, want 1", deleted)
+	}
+
+	// 만료된 게시글의 파일은 사용자 경로에서 내려받을 수 없고 관리자 경로에서는 남아 있음
+	if _, _, err := s.GetFileInfo(ctx, expiredID, 0, false); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetFileInfo(expired) error = %v, want sql.ErrNoRows", err)
+	}
+	if _, _, err := s.GetFileInfo(ctx, expiredID, 0, true); err != nil {
+		t.Errorf("GetFileInfo(expired, includeDeleted): %v", err)
+	}
+	for _, id := range []int{pinnedID, freshID} {
+		if _, _, err := s.GetFileInfo(ctx, id, 0, false); err != nil {
+			t.Errorf("GetFileInfo(%d): %v", id, err)
+		}
+	}
+}
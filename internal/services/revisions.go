@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"file-board/internal/models"
+)
+
+// ErrPostNotModified 수정 요청이 현재 내용과 같음
+var ErrPostNotModified = errors.New("변경된 내용이 없습니다")
+
+// EditPost 관리자 게시글 수정 (수정 전 내용을 post_revisions에 보관, 없는 게시글은 sql.ErrNoRows)
+func (s *PostService) EditPost(ctx context.Context, id int, req models.PostEditRequest, ipAddress string) error {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.WriteTimeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	var postType, title string
+	var content, fileName sql.NullString
+	err = tx.QueryRowContext(ctx, `
+		SELECT post_type, COALESCE(title, ''), content, file_name FROM posts WHERE id = $1 FOR UPDATE
+	`, id).Scan(&postType, &title, &content, &fileName)
+	if err != nil {
+		return err
+	}
+
+	newTitle := strings.TrimSpace(req.Title)
+	newContent, newFileName := content, fileName
+	if utf8.RuneCountInString(newTitle) > 255 {
+		return fmt.Errorf("제목은 255자를 넘을 수 없습니다")
+	}
+
+	// 메시지는 제목 필수, 파일 게시글은 제목 대신 파일명이 표시되므로 파일명 필수
+	if postType == "message" {
+		if newTitle == "" {
+			return fmt.Errorf("제목을 입력해주세요")
+		}
+		newContent = sql.NullString{String: req.Content, Valid: req.Content != ""}
+	} else {
+		name, err := validateDisplayFileName(req.FileName)
+		if err != nil {
+			return err
+		}
+		newFileName = sql.NullString{String: name, Valid: true}
+	}
+
+	if newTitle == title && newContent == content && newFileName == fileName {
+		return ErrPostNotModified
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO post_revisions (post_id, title, content, file_name, edited_ip)
+		VALUES ($1, $2, $3, $4, $5)
+	`, id, title, content, fileName, ipAddress); err != nil {
+		return fmt.Errorf("수정 이력 저장 실패: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE posts SET title = $2, content = $3, file_name = $4 WHERE id = $1
+	`, id, newTitle, newContent, newFileName); err != nil {
+		return fmt.Errorf("게시글 수정 실패: %v", err)
+	}
+
+	// 다운로드 파일명은 post_files 기준이므로 대표 파일(첫 번째 첨부)도 함께 변경
+	if postType == "file" && newFileName != fileName {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE post_files SET file_name = $2 WHERE post_id = $1 AND position = 0
+		`, id, newFileName.String); err != nil {
+			return fmt.Errorf("첨부 파일명 수정 실패: %v", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetRevisions 게시글 수정 이력 조회 (최신순)
+func (s *PostService) GetRevisions(ctx context.Context, postID int) ([]models.PostRevision, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.QueryTimeout)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, post_id, COALESCE(title, ''), COALESCE(content, ''), COALESCE(file_name, ''),
+		       COALESCE(edited_ip, ''), created_at
+		FROM post_revisions
+		WHERE post_id = $1
+		ORDER BY created_at DESC, id DESC
+	`, postID)
+	if err != nil {
+		return nil, fmt.Errorf("수정 이력 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	var revisions []models.PostRevision
+	for rows.Next() {
+		var revision models.PostRevision
+		if err := rows.Scan(
+			&revision.ID, &revision.PostID, &revision.Title, &revision.Content, &revision.FileName,
+			&revision.EditedIP, &revision.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("수정 이력 스캔 실패: %v", err)
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions, rows.Err()
+}
+
+// RevertPost 수정 이력의 내용으로 되돌림 (되돌리기 직전 내용도 새 이력으로 남음)
+func (s *PostService) RevertPost(ctx context.Context, postID, revisionID int, ipAddress string) error {
+	var revision models.PostEditRequest
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COALESCE(title, ''), COALESCE(content, ''), COALESCE(file_name, '')
+		FROM post_revisions
+		WHERE id = $1 AND post_id = $2
+	`, revisionID, postID).Scan(&revision.Title, &revision.Content, &revision.FileName)
+	if err != nil {
+		return err
+	}
+	return s.EditPost(ctx, postID, revision, ipAddress)
+}
+
+// validateDisplayFileName 표시용 파일명 검증 (경로 구분자와 제어 문자 불가)
+func validateDisplayFileName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > 255 {
+		return "", fmt.Errorf("파일명은 1~255자여야 합니다")
+	}
+	if name == "." || name == ".." || strings.ContainsAny(name, `/\`) ||
+		strings.IndexFunc(name, unicode.IsControl) >= 0 {
+		return "", fmt.Errorf("파일명에 경로 구분자나 제어 문자를 사용할 수 없습니다")
+	}
+	return name, nil
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"file-board/internal/models"
+)
+
+// JobScanFile 업로드 파일 악성코드 검사 작업
+const JobScanFile = "scan_file"
+
+type scanFilePayload struct {
+	FileID int `json:"file_id"`
+}
+
+// requestScan 검사 작업을 큐에 추가 (큐에 넣지 못하면 즉시 검사) 후 현재 검사 상태 반환
+func (s *PostService) requestScan(ctx context.Context, fileID int, filePath string) string {
+	if _, err := s.db.ExecContext(ctx,
+		"UPDATE files SET scan_status = 'pending' WHERE id = $1", fileID,
+	); err != nil {
+		slog.ErrorContext(ctx, "검사 상태 갱신 실패", "file_id", fileID, "error", err)
+	}
+
+	if s.jobs != nil {
+		_, err := s.jobs.Enqueue(JobScanFile, scanFilePayload{FileID: fileID})
+		if err == nil {
+			return models.ScanStatusPending
+		}
+		slog.WarnContext(ctx, "검사 작업 추가 실패, 즉시 검사", "file_id", fileID, "error", err)
+	}
+
+	status, err := s.scanFile(ctx, fileID, filePath)
+	if err != nil {
+		slog.ErrorContext(ctx, "파일 검사 실패", "file_id", fileID, "error", err)
+		s.setScanStatus(ctx, fileID, models.ScanStatusError, "", filePath)
+		return models.ScanStatusError
+	}
+	return status
+}
+
+// HandleScanFileJob 검사 작업 처리 (clamd 오류 시 재시도, 최종 실패 처리는 FailScanFileJob)
+func (s *PostService) HandleScanFileJob(ctx context.Context, job *models.Job) error {
+	var payload scanFilePayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return fmt.Errorf("검사 작업 데이터 해석 실패: %v", err)
+	}
+
+	var filePath, scanStatus string
+	err := s.db.QueryRowContext(ctx,
+		"SELECT file_path, scan_status FROM files WHERE id = $1", payload.FileID,
+	).Scan(&filePath, &scanStatus)
+	if err == sql.ErrNoRows {
+		// 게시글 저장에 실패해 정리된 파일
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("검사 대상 파일 조회 실패: %v", err)
+	}
+	if scanStatus == models.ScanStatusClean || scanStatus == models.ScanStatusInfected {
+		return nil
+	}
+
+	if s.scanner == nil {
+		// 검사기 설정이 제거된 경우 다운로드가 막히지 않도록 미검사 상태로 되돌림
+		s.setScanStatus(ctx, payload.FileID, models.ScanStatusUnscanned, "", filePath)
+		return nil
+	}
+
+	if _, err := s.scanFile(ctx, payload.FileID, filePath); err != nil {
+		return err
+	}
+	return nil
+}
+
+// FailScanFileJob 검사 작업이 최종 실패하면 검사 중 상태로 남지 않도록 error 상태 기록
+//
+// 워커가 검사 도중 종료되어 시도 횟수를 넘긴 경우에도 호출되며,
+// 그대로 두면 파일이 계속 ErrFileScanPending으로 다운로드되지 않는다.
+func (s *PostService) FailScanFileJob(ctx context.Context, job *models.Job, jobErr error) {
+	var payload scanFilePayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		slog.ErrorContext(ctx, "검사 작업 데이터 해석 실패", "error", err)
+		return
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE files SET scan_status = $2, scanned_at = NOW()
+		WHERE id = $1 AND scan_status = $3
+	`, payload.FileID, models.ScanStatusError, models.ScanStatusPending)
+	if err != nil {
+		slog.ErrorContext(ctx, "검사 결과 저장 실패", "file_id", payload.FileID, "error", err)
+	}
+}
+
+// scanFile 저장된 파일을 clamd로 검사하고 결과를 files 테이블에 기록 (감염 시 격리)
+func (s *PostService) scanFile(ctx context.Context, fileID int, filePath string) (string, error) {
+	src, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("검사 대상 파일 열기 실패: %v", err)
+	}
+	result, err := s.scanner.Scan(src)
+	src.Close()
+	if err != nil {
+		return "", err
+	}
+
+	if !result.Infected {
+		s.setScanStatus(ctx, fileID, models.ScanStatusClean, "", filePath)
+		return models.ScanStatusClean, nil
+	}
+
+	// 이동 실패 시에도 감염 상태는 기록하여 다운로드 차단
+	quarantinePath, err := quarantineFile(s.cfg.GetQuarantineDir(), filePath)
+	if err != nil {
+		slog.ErrorContext(ctx, "감염 파일 격리 실패", "file_id", fileID, "error", err)
+	}
+
+	slog.WarnContext(ctx, "악성코드 탐지", "file_id", fileID, "signature", result.Signature)
+	s.setScanStatus(ctx, fileID, models.ScanStatusInfected, result.Signature, quarantinePath)
+	return models.ScanStatusInfected, nil
+}
+
+// setScanStatus 검사 결과 기록
+func (s *PostService) setScanStatus(ctx context.Context, fileID int, status, signature, filePath string) {
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE files
+		SET scan_status = $2, scan_signature = NULLIF($3, ''), file_path = $4, scanned_at = NOW()
+		WHERE id = $1
+	`, fileID, status, signature, filePath)
+	if err != nil {
+		slog.ErrorContext(ctx, "검사 결과 저장 실패", "file_id", fileID, "error", err)
+	}
+}
+
+// quarantineFile 파일을 격리 디렉토리로 이동하고 새 경로 반환 (실패하면 원래 경로와 에러 반환)
+func quarantineFile(dir, filePath string) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return filePath, fmt.Errorf("격리 디렉토리 생성 실패: %v", err)
+	}
+	quarantinePath := filepath.Join(dir, filepath.Base(filePath))
+	if err := os.Rename(filePath, quarantinePath); err != nil {
+		return filePath, fmt.Errorf("파일 이동 실패: %v", err)
+	}
+	return quarantinePath, nil
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"file-board/internal/config"
+	"file-board/internal/models"
+	"file-board/internal/scanner"
+)
+
+// startFakeClamd 모든 INSTREAM 요청에 같은 응답을 보내는 가짜 clamd 주소 반환
+func startFakeClamd(t *testing.T, reply string) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				reader := bufio.NewReader(conn)
+				if _, err := reader.ReadString(0); err != nil {
+					return
+				}
+				header := make([]byte, 4)
+				for {
+					if _, err := io.ReadFull(reader, header); err != nil {
+						return
+					}
+					size := binary.BigEndian.Uint32(header)
+					if size == 0 {
+						break
+					}
+					if _, err := io.CopyN(io.Discard, reader, int64(size)); err != nil {
+						return
+					}
+				}
+				io.WriteString(conn, reply+"\x00")
+			}()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+// newScanTestService 검사 테스트용 PostService (address가 비어 있으면 검사기 없음)
+func newScanTestService(t *testing.T, db *sql.DB, address string) *PostService {
+	t.Helper()
+	cfg := &config.Config{}
+	cfg.File.UploadsDir = t.TempDir()
+
+	var fileScanner *scanner.Scanner
+	if address != "" {
+		var err error
+		if fileScanner, err = scanner.New(address, 5*time.Second); err != nil {
+			t.Fatalf("scanner.New: %v", err)
+		}
+	}
+	return &PostService{db: db, cfg: cfg, scanner: fileScanner}
+}
+
+// writeUpload 업로드 디렉토리에 테스트 파일 생성
+func writeUpload(t *testing.T, s *PostService, name string) string {
+	t.Helper()
+	filePath := filepath.Join(s.cfg.File.UploadsDir, name)
+	if err := os.WriteFile(filePath, []byte("payload"), 0644); err != nil {
+		t.Fatalf("write upload: %v", err)
+	}
+	return filePath
+}
+
+func scanJob(t *testing.T, fileID int) *models.Job {
+	t.Helper()
+	payload, err := json.Marshal(scanFilePayload{FileID: fileID})
+	if err != nil {
+		t.Fatalf("marshal payload: %v", err)
+	}
+	return &models.Job{Type: JobScanFile, Payload: payload}
+}
+
+// fileScanState 파일의 검사 상태, 시그니처, 경로 조회
+func fileScanState(t *testing.T, db *sql.DB, fileID int) (status, signature, filePath string) {
+	t.Helper()
+	var sig sql.NullString
+	err := db.QueryRow(
+		"SELECT scan_status, scan_signature, file_path FROM files WHERE id = $1", fileID,
+	).Scan(&status, &sig, &filePath)
+	if err != nil {
+		t.Fatalf("select file %d: %v", fileID, err)
+	}
+	return status, sig.String, filePath
+}
+
+func TestQuarantineFile(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "abcdef0123456789.bin")
+	if err := os.WriteFile(filePath, []byte("infected"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	quarantineDir := filepath.Join(dir, "quarantine")
+	moved, err := quarantineFile(quarantineDir, filePath)
+	if err != nil {
+		t.Fatalf("quarantineFile: %v", err)
+	}
+	if want := filepath.Join(quarantineDir, "abcdef0123456789.bin"); moved != want {
+		t.Errorf("quarantineFile = %q, want %q", moved, want)
+	}
+	if _, err := os.Stat(filePath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("original file still exists: %v", err)
+	}
+	if info, err := os.Stat(quarantineDir); err != nil || info.Mode().Perm() != 0700 {
+		t.Errorf("quarantine dir = %v, %v, want mode 0700", info, err)
+	}
+	if data, err := os.ReadFile(moved); err != nil || string(data) != "infected" {
+		t.Errorf("quarantined file = %q, %v", data, err)
+	}
+}
+
+func TestQuarantineFileMissing(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "missing.bin")
+
+	moved, err := quarantineFile(filepath.Join(dir, "quarantine"), filePath)
+	if err == nil {
+		t.Fatal("quarantineFile error = nil, want error")
+	}
+	if moved != filePath {
+		t.Errorf("quarantineFile = %q, want original path %q on failure", moved, filePath)
+	}
+}
+
+func TestHandleScanFileJobClean(t *testing.T) {
+	db := openTestDB(t)
+	s := newScanTestService(t, db, startFakeClamd(t, "stream: OK"))
+	filePath := writeUpload(t, s, "clean.bin")
+	fileID := insertFile(t, db, "0000000000000001", filePath, models.ScanStatusPending)
+
+	if err := s.HandleScanFileJob(context.Background(), scanJob(t, fileID)); err != nil {
+		t.Fatalf("HandleScanFileJob: %v", err)
+	}
+
+	status, signature, gotPath := fileScanState(t, db, fileID)
+	if status != models.ScanStatusClean || signature != "" || gotPath != filePath {
+		t.Errorf("file = (%q, %q, %q), want clean at %q", status, signature, gotPath, filePath)
+	}
+}
+
+func TestHandleScanFileJobInfected(t *testing.T) {
+	db := openTestDB(t)
+	s := newScanTestService(t, db, startFakeClamd(t, "stream: Eicar-Test-Signature FOUND"))
+	filePath := writeUpload(t, s, "infected.bin")
+	fileID := insertFile(t, db, "0000000000000002", filePath, models.ScanStatusPending)
+
+	if err := s.HandleScanFileJob(context.Background(), scanJob(t, fileID)); err != nil {
+		t.Fatalf("HandleScanFileJob: %v", err)
+	}
+
+	status, signature, gotPath := fileScanState(t, db, fileID)
+	wantPath := filepath.Join(s.cfg.GetQuarantineDir(), "infected.bin")
+	if status != models.ScanStatusInfected || signature != "Eicar-Test-Signature" || gotPath != wantPath {
+		t.Errorf("file = (%q, %q, %q), want infected at %q", status, signature, gotPath, wantPath)
+	}
+	if _, err := os.Stat(filePath); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("infected file left in uploads: %v", err)
+	}
+	if _, err := os.Stat(wantPath); err != nil {
+		t.Errorf("quarantined file missing: %v", err)
+	}
+}
+
+func TestHandleScanFileJobScannerError(t *testing.T) {
+	db := openTestDB(t)
+	s := newScanTestService(t, db, startFakeClamd(t, "INSTREAM size limit exceeded. ERROR"))
+	filePath := writeUpload(t, s, "error.bin")
+	fileID := insertFile(t, db, "0000000000000003", filePath, models.ScanStatusPending)
+
+	// clamd 오류는 재시도하도록 에러를 반환하고 상태는 그대로 둠
+	if err := s.HandleScanFileJob(context.Background(), scanJob(t, fileID)); err == nil {
+		t.Fatal("HandleScanFileJob error = nil, want scanner error")
+	}
+	if status, _, _ := fileScanState(t, db, fileID); status != models.ScanStatusPending {
+		t.Errorf("scan_status = %q, want %q", status, models.ScanStatusPending)
+	}
+}
+
+func TestHandleScanFileJobSkips(t *testing.T) {
+	db := openTestDB(t)
+	s := newScanTestService(t, db, "")
+
+	// 정리된 파일은 재시도하지 않음
+	if err := s.HandleScanFileJob(context.Background(), scanJob(t, 999999)); err != nil {
+		t.Errorf("HandleScanFileJob(missing file): %v", err)
+	}
+
+	// 이미 검사가 끝난 파일은 다시 검사하지 않음
+	cleanID := insertFile(t, db, "0000000000000004", "clean.bin", models.ScanStatusClean)
+	if err := s.HandleScanFileJob(context.Background(), scanJob(t, cleanID)); err != nil {
+		t.Errorf("HandleScanFileJob(clean): %v", err)
+	}
+	if status, _, _ := fileScanState(t, db, cleanID); status != models.ScanStatusClean {
+		t.Errorf("clean file scan_status = %q", status)
+	}
+
+	// 검사기가 없으면 미검사 상태로 되돌림
+	pendingID := insertFile(t, db, "0000000000000005", "pending.bin", models.ScanStatusPending)
+	if err := s.HandleScanFileJob(context.Background(), scanJob(t, pendingID)); err != nil {
+		t.Errorf("HandleScanFileJob(no scanner): %v", err)
+	}
+	if status, _, _ := fileScanState(t, db, pendingID); status != models.ScanStatusUnscanned {
+		t.Errorf("scan_status without scanner = %q, want %q", status, models.ScanStatusUnscanned)
+	}
+
+	if err := s.HandleScanFileJob(context.Background(), &models.Job{Payload: json.RawMessage("{")}); err == nil {
+		t.Error("HandleScanFileJob(invalid payload) error = nil, want error")
+	}
+}
+
+func TestFailScanFileJob(t *testing.T) {
+	db := openTestDB(t)
+	s := newScanTestService(t, db, "")
+
+	pendingID := insertFile(t, db, "0000000000000006", "pending.bin", models.ScanStatusPending)
+	cleanID := insertFile(t, db, "0000000000000007", "clean.bin", models.ScanStatusClean)
+	infectedID := insertFile(t, db, "0000000000000008", "infected.bin", models.ScanStatusInfected)
+
+	jobErr := errors.New("clamd unavailable")
+	for _, id := range []int{pendingID, cleanID, infectedID} {
+		s.FailScanFileJob(context.Background(), scanJob(t, id), jobErr)
+	}
+
+	// 검사 중 상태만 error로 바뀌고 이미 결과가 기록된 파일은 유지
+	for id, want := range map[int]string{
+		pendingID:  models.ScanStatusError,
+		cleanID:    models.ScanStatusClean,
+		infectedID: models.ScanStatusInfected,
+	} {
+		if status, _, _ := fileScanState(t, db, id); status != want {
+			t.Errorf("file %d scan_status = %q, want %q", id, status, want)
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"file-board/internal/config"
+	"file-board/internal/models"
+)
+
+// SettingsService 운영 설정 관리 (settings 테이블 값을 환경 설정 기본값 위에 덮어써 적용)
+//
+// 현재 설정은 atomic 포인터로 교체하므로 요청 처리 중에는 잠금 없이 읽는다.
+// 다른 프로세스에서 바꾼 값은 Reload(SIGHUP 또는 관리자 페이지) 시점에 반영된다.
+type SettingsService struct {
+	db       *sql.DB
+	defaults models.Settings
+	current  atomic.Pointer[models.Settings]
+	mu       sync.Mutex // Reload/Update 직렬화
+}
+
+func NewSettingsService(db *sql.DB, cfg *config.Config) *SettingsService {
+	s := &SettingsService{db: db, defaults: DefaultSettings(cfg)}
+	defaults := s.defaults
+	s.current.Store(&defaults)
+	return s
+}
+
+// DefaultSettings 환경 설정에서 가져온 운영 설정 기본값
+func DefaultSettings(cfg *config.Config) models.Settings {
+	return models.Settings{
+		SiteTitle:        "🌱 새싹 공유 게시판",
+		AnonymousPosting: true,
+
+		UploadsEnabled:    true,
+		MaxFileSizeMB:     cfg.GetMaxFileSizeMB(),
+		MaxFilesPerPost:   cfg.File.MaxFilesPerPost,
+		MaxFolderFiles:    cfg.File.MaxFolderFiles,
+		AllowedExtensions: []string{},
+		BlockedExtensions: []string{},
+
+		CommentsEnabled:      true,
+		CommentMaxLength:     cfg.Comment.MaxLength,
+		CommentRateLimit:     cfg.Comment.RateLimit,
+		CommentRateWindowSec: int(cfg.Comment.RateWindow.Seconds()),
+	}
+}
+
+// Current 현재 적용 중인 설정 (반환값은 수정하지 말 것)
+func (s *SettingsService) Current() *models.Settings {
+	return s.current.Load()
+}
+
+// Defaults 환경 설정 기본값
+func (s *SettingsService) Defaults() models.Settings {
+	return s.defaults
+}
+
+// Overrides settings 테이블에 저장된 값 (키 → JSON 값)
+func (s *SettingsService) Overrides(ctx context.Context) (map[string]json.RawMessage, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT key, value FROM settings")
+	if err != nil {
+		return nil, fmt.Errorf("설정 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	overrides := map[string]json.RawMessage{}
+	for rows.Next() {
+		var key string
+		var value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("설정 스캔 실패: %v", err)
+		}
+		overrides[key] = value
+	}
+	return overrides, rows.Err()
+}
+
+// Reload settings 테이블을 다시 읽어 적용 (값이 올바르지 않으면 기존 설정 유지)
+func (s *SettingsService) Reload(ctx context.Context) (*models.Settings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	overrides, err := s.Overrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := s.knownKeys()
+	for key := range overrides {
+		if !known[key] {
+			// 이전 버전에서 저장한 키 등은 무시
+			slog.WarnContext(ctx, "알 수 없는 설정 키 무시", "key", key)
+			delete(overrides, key)
+		}
+	}
+
+	settings, err := s.merge(overrides)
+	if err != nil {
+		return nil, err
+	}
+	s.current.Store(settings)
+	slog.InfoContext(ctx, "운영 설정 적용", "overrides", sortedKeys(overrides))
+	return settings, nil
+}
+
+// Update 설정 변경 후 즉시 적용 (값이 null이면 저장된 값을 지우고 기본값으로 되돌림)
+func (s *SettingsService) Update(ctx context.Context, changes map[string]json.RawMessage) (*models.Settings, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	known := s.knownKeys()
+	for key := range changes {
+		if !known[key] {
+			return nil, fmt.Errorf("알 수 없는 설정: %s", key)
+		}
+	}
+
+	overrides, err := s.Overrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for key := range overrides {
+		if !known[key] {
+			delete(overrides, key)
+		}
+	}
+	for key, value := range changes {
+		if isJSONNull(value) {
+			delete(overrides, key)
+		} else {
+			overrides[key] = value
+		}
+	}
+
+	// 저장하기 전에 합친 결과를 검사
+	settings, err := s.merge(overrides)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	for key, value := range changes {
+		if isJSONNull(value) {
+			_, err = tx.ExecContext(ctx, "DELETE FROM settings WHERE key = $1", key)
+		} else {
+			_, err = tx.ExecContext(ctx, `
+				INSERT INTO settings (key, value, updated_at) VALUES ($1, $2, NOW())
+				ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value, updated_at = NOW()
+			`, key, []byte(overrides[key]))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("설정 저장 실패: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("설정 저장 실패: %v", err)
+	}
+
+	s.current.Store(settings)
+	slog.InfoContext(ctx, "운영 설정 변경", "keys", sortedKeys(changes))
+	return settings, nil
+}
+
+// merge 기본값 위에 저장된 값을 덮어써 검사된 설정 생성
+func (s *SettingsService) merge(overrides map[string]json.RawMessage) (*models.Settings, error) {
+	fields, err := s.defaultFields()
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range overrides {
+		fields[key] = value
+	}
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("설정 변환 실패: %v", err)
+	}
+
+	var settings models.Settings
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&settings); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, fmt.Errorf("%s: 값의 형식이 올바르지 않습니다", typeErr.Field)
+		}
+		return nil, fmt.Errorf("설정 해석 실패: %v", err)
+	}
+
+	settings.Normalize()
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// defaultFields 기본값을 JSON 키별로 나눈 맵
+func (s *SettingsService) defaultFields() (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(s.defaults)
+	if err != nil {
+		return nil, fmt.Errorf("설정 변환 실패: %v", err)
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("설정 변환 실패: %v", err)
+	}
+	return fields, nil
+}
+
+// knownKeys 바꿀 수 있는 설정 키 (models.Settings의 JSON 키)
+func (s *SettingsService) knownKeys() map[string]bool {
+	fields, _ := s.defaultFields()
+	known := make(map[string]bool, len(fields))
+	for key := range fields {
+		known[key] = true
+	}
+	return known
+}
+
+func isJSONNull(value json.RawMessage) bool {
+	return len(bytes.TrimSpace(value)) == 0 || string(bytes.TrimSpace(value)) == "null"
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"file-board/internal/config"
+)
+
+func newSettingsTestService() *SettingsService {
+	cfg := &config.Config{}
+	cfg.File.MaxFileSize = 500 << 20
+	cfg.File.MaxFilesPerPost = 20
+	cfg.File.MaxFolderFiles = 500
+	cfg.Comment.MaxLength = 2000
+	cfg.Comment.RateLimit = 5
+	cfg.Comment.RateWindow = time.Minute
+	return NewSettingsService(nil, cfg)
+}
+
+func TestSettingsMerge(t *testing.T) {
+	s := newSettingsTestService()
+
+	settings, err := s.merge(nil)
+	if err != nil {
+		t.Fatalf("merge(defaults): %v", err)
+	}
+	if settings.MaxFileSizeMB != 500 || !settings.UploadsEnabled || settings.CommentRateWindowSec != 60 {
+		t.Errorf("defaults = %+v", settings)
+	}
+
+	settings, err = s.merge(map[string]json.RawMessage{
+		"site_title":         json.RawMessage(`"  자료실 "`),
+		"uploads_enabled":    json.RawMessage(`false`),
+		"blocked_extensions": json.RawMessage(`[".EXE", "exe", "bat"]`),
+		"retention_days":     json.RawMessage(`30`),
+	})
+	if err != nil {
+		t.Fatalf("merge(overrides): %v", err)
+	}
+	if settings.SiteTitle != "자료실" || settings.UploadsEnabled || settings.RetentionDays != 30 {
+		t.Errorf("merged = %+v", settings)
+	}
+	if strings.Join(settings.BlockedExtensions, ",") != "exe,bat" {
+		t.Errorf("BlockedExtensions = %v, want [exe bat]", settings.BlockedExtensions)
+	}
+	if settings.MaxFilesPerPost != 20 {
+		t.Errorf("untouched MaxFilesPerPost = %d, want default 20", settings.MaxFilesPerPost)
+	}
+}
+
+func TestSettingsMergeErrors(t *testing.T) {
+	s := newSettingsTestService()
+	tests := []struct {
+		name      string
+		overrides map[string]json.RawMessage
+		want      string
+	}{
+		{"type error", map[string]json.RawMessage{"max_file_size_mb": json.RawMessage(`"big"`)}, "max_file_size_mb: 값의 형식이 올바르지 않습니다"},
+		{"bool type error", map[string]json.RawMessage{"uploads_enabled": json.RawMessage(`"yes"`)}, "uploads_enabled"},
+		{"unknown key", map[string]json.RawMessage{"site_titel": json.RawMessage(`"x"`)}, "site_titel"},
+		{"invalid value", map[string]json.RawMessage{"retention_days": json.RawMessage(`-1`)}, "보관 기간"},
+		{"blank title", map[string]json.RawMessage{"site_title": json.RawMessage(`"   "`)}, "게시판 제목"},
+	}
+	for _, tt := range tests {
+		if _, err := s.merge(tt.overrides); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: merge() error = %v, want it to contain %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestSettingsNullResets(t *testing.T) {
+	for _, value := range []string{``, `null`, ` null `} {
+		if !isJSONNull(json.RawMessage(value)) {
+			t.Errorf("isJSONNull(%q) = false", value)
+		}
+	}
+	for _, value := range []string{`0`, `""`, `false`, `[]`} {
+		if isJSONNull(json.RawMessage(value)) {
+			t.Errorf("isJSONNull(%q) = true", value)
+		}
+	}
+}
+
+func TestSettingsUpdateRejectsUnknownKeys(t *testing.T) {
+	// 알 수 없는 키는 DB에 접근하기 전에 거부
+	s := newSettingsTestService()
+	_, err := s.Update(context.Background(), map[string]json.RawMessage{"site_title": json.RawMessage(`"x"`), "nope": json.RawMessage(`1`)})
+	if err == nil || !strings.Contains(err.Error(), "알 수 없는 설정: nope") {
+		t.Errorf("Update() error = %v, want unknown key error", err)
+	}
+}
+
+func TestSettingsUpdate(t *testing.T) {
+	db := openTestDB(t)
+	s := newSettingsTestService()
+	s.db = db
+	ctx := context.Background()
+
+	settings, err := s.Update(ctx, map[string]json.RawMessage{
+		"max_files_per_post": json.RawMessage(`5`),
+		"notice_banner":      json.RawMessage(`"점검 중"`),
+	})
+	if err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if settings.MaxFilesPerPost != 5 || s.Current().NoticeBanner != "점검 중" {
+		t.Errorf("updated = %+v", settings)
+	}
+
+	// 잘못된 값은 저장하지 않고 현재 설정도 유지
+	if _, err := s.Update(ctx, map[string]json.RawMessage{"max_files_per_post": json.RawMessage(`0`)}); err == nil {
+		t.Error("Update(max_files_per_post=0) succeeded")
+	}
+	if s.Current().MaxFilesPerPost != 5 {
+		t.Errorf("MaxFilesPerPost after rejected update = %d, want 5", s.Current().MaxFilesPerPost)
+	}
+
+	// null은 저장된 값을 지우고 기본값으로 되돌림
+	settings, err = s.Update(ctx, map[string]json.RawMessage{"max_files_per_post": json.RawMessage(`null`)})
+	if err != nil {
+		t.Fatalf("Update(null): %v", err)
+	}
+	if settings.MaxFilesPerPost != 20 || settings.NoticeBanner != "점검 중" {
+		t.Errorf("after reset = %+v", settings)
+	}
+	overrides, err := s.Overrides(ctx)
+	if err != nil {
+		t.Fatalf("Overrides: %v", err)
+	}
+	if _, ok := overrides["max_files_per_post"]; ok || len(overrides) != 1 {
+		t.Errorf("overrides = %v, want only notice_banner", sortedKeys(overrides))
+	}
+
+	// 다른 프로세스에서 바꾼 값은 Reload로 반영
+	if _, err := db.Exec(`UPDATE settings SET value = '"새 안내"' WHERE key = 'notice_banner'`); err != nil {
+		t.Fatalf("update settings: %v", err)
+	}
+	if settings, err := s.Reload(ctx); err != nil || settings.NoticeBanner != "새 안내" {
+		t.Errorf("Reload() = %+v, %v", settings, err)
+	}
+}
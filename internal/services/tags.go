@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"file-board/internal/models"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// MaxTagsPerPost 게시글 하나에 붙일 수 있는 최대 태그 수
+	MaxTagsPerPost = 10
+	// MaxTagLength 태그 최대 길이 (글자 수)
+	MaxTagLength = 30
+)
+
+// ParseTags 쉼표로 구분한 태그 입력을 정규화 (앞의 #과 공백 제거, 소문자 변환, 중복 제거)
+func ParseTags(input string) ([]string, error) {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(input, ",") {
+		tag := strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(part), "#")))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, fmt.Errorf("태그는 %d자를 초과할 수 없습니다: %s", MaxTagLength, tag)
+		}
+		if strings.IndexFunc(tag, unicode.IsControl) >= 0 {
+			return nil, fmt.Errorf("태그에 사용할 수 없는 문자가 있습니다")
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	if len(tags) > MaxTagsPerPost {
+		return nil, fmt.Errorf("태그는 %d개까지 붙일 수 있습니다", MaxTagsPerPost)
+	}
+	return tags, nil
+}
+
+// saveTags 게시글 태그 저장 (없는 태그는 새로 만듦)
+func saveTags(ctx context.Context, tx *sql.Tx, postID int, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO tags (name) SELECT unnest($1::text[]) ON CONFLICT (name) DO NOTHING
+	`, pq.Array(tags)); err != nil {
+		return fmt.Errorf("태그 저장 실패: %v", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO post_tags (post_id, tag_id)
+		SELECT $1, id FROM tags WHERE name = ANY($2)
+		ON CONFLICT DO NOTHING
+	`, postID, pq.Array(tags)); err != nil {
+		return fmt.Errorf("게시글 태그 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// loadTags 게시글들의 태그를 한 번에 조회하여 채움
+func (s *PostService) loadTags(ctx context.Context, posts []models.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	index := make(map[int]*models.Post, len(posts))
+	ids := make([]int64, 0, len(posts))
+	for i := range posts {
+		index[posts[i].ID] = &posts[i]
+		ids = append(ids, int64(posts[i].ID))
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT pt.post_id, t.name
+		FROM post_tags pt
+		JOIN tags t ON pt.tag_id = t.id
+		WHERE pt.post_id = ANY($1)
+		ORDER BY pt.post_id, t.name
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("태그 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID int
+		var name string
+		if err := rows.Scan(&postID, &name); err != nil {
+			return fmt.Errorf("태그 스캔 실패: %v", err)
+		}
+		if post, ok := index[postID]; ok {
+			post.Tags = append(post.Tags, name)
+		}
+	}
+	return rows.Err()
+}
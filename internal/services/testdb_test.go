@@ -0,0 +1,112 @@
+package services
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+// openTestDB TEST_DATABASE_URL의 데이터베이스에 테스트 전용 스키마를 만들고
+// db/init.sql과 마이그레이션을 적용한 연결을 반환 (환경 변수가 없으면 건너뜀)
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL이 설정되지 않아 DB 테스트를 건너뜁니다")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer admin.Close()
+
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		cleanup, err := sql.Open("postgres", dsn)
+		if err != nil {
+			return
+		}
+		defer cleanup.Close()
+		cleanup.Exec("DROP SCHEMA " + schema + " CASCADE")
+	})
+
+	db, err := sql.Open("postgres", withSearchPath(t, dsn, schema))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	files := []string{filepath.Join("..", "..", "db", "init.sql")}
+	migrations, err := filepath.Glob(filepath.Join("..", "database", "migrations", "*.sql"))
+	if err != nil {
+		t.Fatalf("glob migrations: %v", err)
+	}
+	sort.Strings(migrations)
+	for _, name := range append(files, migrations...) {
+		body, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if _, err := db.Exec(string(body)); err != nil {
+			t.Fatalf("apply %s: %v", name, err)
+		}
+	}
+	return db
+}
+
+// withSearchPath DSN에 search_path 런타임 파라미터 추가 (URL, key=value 형식 모두 지원)
+func withSearchPath(t *testing.T, dsn, schema string) string {
+	t.Helper()
+	if !strings.HasPrefix(dsn, "postgres://") && !strings.HasPrefix(dsn, "postgresql://") {
+		return dsn + " search_path=" + schema
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("parse TEST_DATABASE_URL: %v", err)
+	}
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// insertPost 테스트용 게시글 추가 후 ID 반환
+func insertPost(t *testing.T, db *sql.DB, postType, ipAddress string, fileID sql.NullInt64) int {
+	t.Helper()
+	var id int
+	err := db.QueryRow(`
+		INSERT INTO posts (title, post_type, ip_address, file_id, board_id)
+		VALUES ('test', $1, $2, $3, (SELECT id FROM boards ORDER BY position, id LIMIT 1))
+		RETURNING id
+	`, postType, ipAddress, fileID).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert post: %v", err)
+	}
+	return id
+}
+
+// insertFile 테스트용 파일 행 추가 후 ID 반환
+func insertFile(t *testing.T, db *sql.DB, hash, filePath, scanStatus string) int {
+	t.Helper()
+	var id int
+	err := db.QueryRow(`
+		INSERT INTO files (file_hash, file_path, file_size, mime_type, scan_status)
+		VALUES ($1, $2, 0, 'application/octet-stream', $3) RETURNING id
+	`, hash, filePath, scanStatus).Scan(&id)
+	if err != nil {
+		t.Fatalf("insert file: %v", err)
+	}
+	return id
+}
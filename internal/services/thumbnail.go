@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+
+	"file-board/internal/models"
+	"file-board/internal/thumbnail"
+)
+
+// JobThumbnail 이미지 썸네일 생성 작업
+const JobThumbnail = "thumbnail"
+
+// file_hash 형식 (FNV 64bit 16진수)
+var fileHashPattern = regexp.MustCompile(`^[0-9a-f]{1,16}$`)
+
+type thumbnailPayload struct {
+	FileID int `json:"file_id"`
+}
+
+// requestThumbnail 썸네일 생성 작업을 큐에 추가
+func (s *PostService) requestThumbnail(ctx context.Context, fileID int) {
+	if s.jobs == nil {
+		return
+	}
+	if _, err := s.jobs.Enqueue(JobThumbnail, thumbnailPayload{FileID: fileID}); err != nil {
+		slog.ErrorContext(ctx, "썸네일 작업 추가 실패", "file_id", fileID, "error", err)
+	}
+}
+
+// HandleThumbnailJob 썸네일 생성 작업 처리
+func (s *PostService) HandleThumbnailJob(ctx context.Context, job *models.Job) error {
+	var payload thumbnailPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return fmt.Errorf("썸네일 작업 데이터 해석 실패: %v", err)
+	}
+
+	var fileHash, filePath, mimeType, scanStatus string
+	var hasThumbnail bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT file_hash, file_path, COALESCE(mime_type, ''), scan_status, has_thumbnail
+		FROM files WHERE id = $1
+	`, payload.FileID).Scan(&fileHash, &filePath, &mimeType, &scanStatus, &hasThumbnail)
+	if err == sql.ErrNoRows {
+		// 게시글 저장에 실패해 정리된 파일
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("썸네일 대상 파일 조회 실패: %v", err)
+	}
+
+	// 이미 생성되었거나, 격리되었거나, 지원하지 않는 형식이면 건너뜀
+	if hasThumbnail || scanStatus == models.ScanStatusInfected || !thumbnail.IsSupported(mimeType) {
+		return nil
+	}
+
+	if err := thumbnail.Generate(filePath, s.thumbnailPath(fileHash), s.cfg.File.ThumbnailSize); err != nil {
+		return err
+	}
+
+	if _, err := s.db.ExecContext(ctx, "UPDATE files SET has_thumbnail = TRUE WHERE id = $1", payload.FileID); err != nil {
+		return fmt.Errorf("썸네일 상태 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// GetThumbnailPath file_hash로 썸네일 파일 경로 조회 (검사를 통과했고 삭제되지 않은 게시글에 첨부된 파일만)
+//
+// includeDeleted가 true이면 삭제된 게시글에만 남은 파일도 포함한다 (관리자 서버).
+func (s *PostService) GetThumbnailPath(ctx context.Context, fileHash string, includeDeleted bool) (string, error) {
+	ctx, cancel := withTimeout(ctx, s.cfg.Database.QueryTimeout)
+	defer cancel()
+
+	if !fileHashPattern.MatchString(fileHash) {
+		return "", sql.ErrNoRows
+	}
+
+	var exists bool
+	err := s.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM files f
+			WHERE f.file_hash = $1 AND f.has_thumbnail AND f.scan_status IN ($2, $3)
+			  AND (
+				EXISTS (
+					SELECT 1 FROM post_files pf JOIN posts p ON p.id = pf.post_id
+					WHERE pf.file_id = f.id AND ($4 OR p.deleted_at IS NULL)
+				)
+				OR EXISTS (SELECT 1 FROM posts p WHERE p.file_id = f.id AND ($4 OR p.deleted_at IS NULL))
+			  )
+		)
+	`, fileHash, models.ScanStatusClean, models.ScanStatusUnscanned, includeDeleted).Scan(&exists)
+	if err != nil {
+		return "", fmt.Errorf("썸네일 조회 실패: %v", err)
+	}
+	if !exists {
+		return "", sql.ErrNoRows
+	}
+
+	return s.thumbnailPath(fileHash), nil
+}
+
+// thumbnailPath file_hash 기준 썸네일 저장 경로
+func (s *PostService) thumbnailPath(fileHash string) string {
+	return filepath.Join(s.cfg.GetThumbnailDir(), fileHash+".jpg")
+}
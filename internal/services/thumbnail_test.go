@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"file-board/internal/config"
+	"file-board/internal/models"
+)
+
+func TestGetThumbnailPath(t *testing.T) {
+	db := openTestDB(t)
+	s := &PostService{db: db, cfg: &config.Config{}}
+
+	// file_hash → 썸네일을 제공해야 하는지
+	files := map[string]struct {
+		scanStatus string
+		deleted    bool
+		attached   bool
+		want       bool
+	}{
+		"00000000000000a1": {scanStatus: models.ScanStatusClean, attached: true, want: true},
+		"00000000000000a2": {scanStatus: models.ScanStatusUnscanned, attached: true, want: true},
+		"00000000000000a3": {scanStatus: models.ScanStatusPending, attached: true},
+		"00000000000000a4": {scanStatus: models.ScanStatusError, attached: true},
+		"00000000000000a5": {scanStatus: models.ScanStatusInfected, attached: true},
+		"00000000000000a6": {scanStatus: models.ScanStatusClean, attached: true, deleted: true},
+		"00000000000000a7": {scanStatus: models.ScanStatusClean},
+	}
+	for hash, f := range files {
+		fileID := insertFile(t, db, hash, hash+".bin", f.scanStatus)
+		if _, err := db.Exec("UPDATE files SET has_thumbnail = TRUE WHERE id = $1", fileID); err != nil {
+			t.Fatalf("update file: %v", err)
+		}
+		if !f.attached {
+			continue
+		}
+		postID := insertPost(t, db, "file", "203.0.113.7", sql.NullInt64{Int64: int64(fileID), Valid: true})
+		if _, err := db.Exec(`INSERT INTO post_files (post_id, file_id, file_name) VALUES ($1, $2, 'a.png')`, postID, fileID); err != nil {
+			t.Fatalf("insert post file: %v", err)
+		}
+		if f.deleted {
+			if _, err := db.Exec("UPDATE posts SET deleted_at = NOW() WHERE id = $1", postID); err != nil {
+				t.Fatalf("delete post: %v", err)
+			}
+		}
+	}
+
+	for hash, f := range files {
+		path, err := s.GetThumbnailPath(context.Background(), hash, false)
+		switch {
+		case f.want && err != nil:
+			t.Errorf("GetThumbnailPath(%s): %v", hash, err)
+		case !f.want && err != sql.ErrNoRows:
+			t.Errorf("GetThumbnailPath(%s) = %q, %v, want sql.ErrNoRows", hash, path, err)
+		}
+	}
+
+	// 관리자 서버는 삭제된 게시글의 썸네일도 제공
+	if _, err := s.GetThumbnailPath(context.Background(), "00000000000000a6", true); err != nil {
+		t.Errorf("GetThumbnailPath(deleted post, includeDeleted): %v", err)
+	}
+	if _, err := s.GetThumbnailPath(context.Background(), "00000000000000a7", true); err != sql.ErrNoRows {
+		t.Errorf("GetThumbnailPath(unattached, includeDeleted) error = %v, want sql.ErrNoRows", err)
+	}
+
+	if _, err := s.GetThumbnailPath(context.Background(), "../etc/passwd", false); err != sql.ErrNoRows {
+		t.Errorf("GetThumbnailPath(invalid hash) error = %v, want sql.ErrNoRows", err)
+	}
+}
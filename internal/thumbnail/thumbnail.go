@@ -0,0 +1,107 @@
+// Package thumbnail 이미지 업로드의 썸네일 생성 (JPEG/PNG/GIF/WebP, 순수 Go 디코딩)
+package thumbnail
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"os"
+	"path/filepath"
+	"strings"
+
+	// 디코더 등록
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// 디코딩을 허용하는 최대 픽셀 수 (압축 폭탄 방지)
+const maxPixels = 50_000_000
+
+// 썸네일 JPEG 품질
+const jpegQuality = 80
+
+// IsSupported 썸네일을 생성할 수 있는 MIME 타입인지 확인
+func IsSupported(mimeType string) bool {
+	switch strings.ToLower(mimeType) {
+	case "image/jpeg", "image/png", "image/gif", "image/webp":
+		return true
+	}
+	return false
+}
+
+// Generate 원본 이미지를 긴 변 기준 maxSize 픽셀 이하로 축소하여 JPEG로 저장
+func Generate(srcPath, dstPath string, maxSize int) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("원본 이미지 열기 실패: %v", err)
+	}
+	defer src.Close()
+
+	// 전체 디코딩 전에 크기 확인
+	config, _, err := image.DecodeConfig(src)
+	if err != nil {
+		return fmt.Errorf("이미지 정보 읽기 실패: %v", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return fmt.Errorf("썸네일을 만들 수 없는 이미지 크기: %dx%d", config.Width, config.Height)
+	}
+
+	if _, err := src.Seek(0, 0); err != nil {
+		return fmt.Errorf("원본 이미지 읽기 실패: %v", err)
+	}
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return fmt.Errorf("이미지 디코딩 실패: %v", err)
+	}
+
+	thumb := resize(img, maxSize)
+
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0755); err != nil {
+		return fmt.Errorf("썸네일 디렉토리 생성 실패: %v", err)
+	}
+
+	// 임시 파일에 쓴 뒤 교체하여 불완전한 썸네일이 노출되지 않도록 함
+	tmp, err := os.CreateTemp(filepath.Dir(dstPath), ".thumb-*")
+	if err != nil {
+		return fmt.Errorf("썸네일 파일 생성 실패: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := jpeg.Encode(tmp, thumb, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		tmp.Close()
+		return fmt.Errorf("썸네일 인코딩 실패: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("썸네일 저장 실패: %v", err)
+	}
+
+	if err := os.Rename(tmp.Name(), dstPath); err != nil {
+		return fmt.Errorf("썸네일 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// resize 비율을 유지하며 축소 (투명 영역은 흰 배경으로 채움)
+func resize(img image.Image, maxSize int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			height = max(1, height*maxSize/width)
+			width = maxSize
+		} else {
+			width = max(1, width*maxSize/height)
+			height = maxSize
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Over, nil)
+	return dst
+}
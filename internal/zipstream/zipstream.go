@@ -0,0 +1,116 @@
+// Package zipstream 디스크의 파일들을 임시 파일 없이 ZIP으로 바로 스트리밍
+package zipstream
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 파일명 UTF-8 인코딩 플래그 (일반 목적 비트 11)
+const flagUTF8 = 0x800
+
+// Entry ZIP에 담을 파일
+type Entry struct {
+	Name     string // ZIP 안에서의 경로 (폴더 구분자는 "/", 중복 시 자동으로 번호를 붙임)
+	Path     string // 디스크 경로
+	MimeType string
+	ModTime  time.Time
+}
+
+// Write 항목들을 순서대로 ZIP으로 기록, 디스크에서 찾을 수 없는 파일은 건너뛰고 건너뛴 이름 목록 반환
+//
+// 응답 헤더를 보낸 뒤에 호출되므로 쓰기 에러가 나면 ZIP이 중간에 끊긴다.
+func Write(w io.Writer, entries []Entry) ([]string, error) {
+	zw := zip.NewWriter(w)
+	names := newNameSet()
+	var skipped []string
+
+	for _, entry := range entries {
+		src, err := os.Open(entry.Path)
+		if err != nil {
+			skipped = append(skipped, entry.Name)
+			continue
+		}
+
+		header := &zip.FileHeader{
+			Name:     names.unique(SanitizePath(entry.Name)),
+			Method:   method(entry.MimeType),
+			Modified: entry.ModTime,
+			Flags:    flagUTF8,
+		}
+		dst, err := zw.CreateHeader(header)
+		if err != nil {
+			src.Close()
+			return skipped, fmt.Errorf("ZIP 항목 생성 실패: %v", err)
+		}
+		_, err = io.Copy(dst, src)
+		src.Close()
+		if err != nil {
+			return skipped, fmt.Errorf("ZIP 항목 기록 실패: %v", err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return skipped, fmt.Errorf("ZIP 마무리 실패: %v", err)
+	}
+	return skipped, nil
+}
+
+// SanitizePath ZIP 항목 경로에서 절대 경로와 상위 경로 이동을 제거 (zip slip 방지, 폴더 구조는 유지)
+func SanitizePath(name string) string {
+	name = strings.ReplaceAll(name, "\\", "/")
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+	// 루트 기준으로 정리하면 ".."가 루트 밖으로 나갈 수 없음
+	name = strings.TrimPrefix(path.Clean("/"+name), "/")
+	if name == "" {
+		return "file"
+	}
+	return name
+}
+
+// method 이미 압축된 형식은 그대로 저장하고 나머지는 deflate 압축
+func method(mimeType string) uint16 {
+	switch {
+	case strings.HasPrefix(mimeType, "image/") && mimeType != "image/svg+xml" && mimeType != "image/bmp",
+		strings.HasPrefix(mimeType, "video/"),
+		strings.HasPrefix(mimeType, "audio/"):
+		return zip.Store
+	}
+	switch mimeType {
+	case "application/zip", "application/gzip", "application/x-7z-compressed",
+		"application/x-rar-compressed", "application/vnd.rar", "application/x-xz",
+		"application/x-bzip2", "application/zstd", "application/pdf":
+		return zip.Store
+	}
+	return zip.Deflate
+}
+
+// nameSet ZIP 안의 이름 중복 방지 (대소문자 구분 없이 비교)
+type nameSet map[string]bool
+
+func newNameSet() nameSet {
+	return make(nameSet)
+}
+
+// unique 이미 사용된 이름이면 "이름 (2).확장자" 형태로 번호를 붙여 반환
+func (s nameSet) unique(name string) string {
+	candidate := name
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	for i := 2; s[strings.ToLower(candidate)]; i++ {
+		candidate = base + " (" + strconv.Itoa(i) + ")" + ext
+	}
+	s[strings.ToLower(candidate)] = true
+	return candidate
+}
@@ -0,0 +1,157 @@
+package zipstream
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeFile 임시 디렉토리에 내용을 기록하고 경로 반환
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	filePath := filepath.Join(dir, name)
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return filePath
+}
+
+// readZip 버퍼의 ZIP을 읽어 항목 목록 반환
+func readZip(t *testing.T, buf *bytes.Buffer) []*zip.File {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader: %v", err)
+	}
+	return zr.File
+}
+
+func readEntry(t *testing.T, f *zip.File) string {
+	t.Helper()
+	rc, err := f.Open()
+	if err != nil {
+		t.Fatalf("open %s: %v", f.Name, err)
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("read %s: %v", f.Name, err)
+	}
+	return string(data)
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	modTime := time.Date(2025, 8, 11, 17, 30, 0, 0, time.UTC)
+	entries := []Entry{
+		{Name: "보고서.txt", Path: writeFile(t, dir, "1", "first"), MimeType: "text/plain", ModTime: modTime},
+		{Name: "보고서.txt", Path: writeFile(t, dir, "2", "second"), MimeType: "text/plain", ModTime: modTime},
+		{Name: "보고서 (2).txt", Path: writeFile(t, dir, "3", "third"), MimeType: "text/plain", ModTime: modTime},
+		{Name: "REPORT.TXT", Path: writeFile(t, dir, "4", "upper"), MimeType: "text/plain", ModTime: modTime},
+		{Name: "report.txt", Path: writeFile(t, dir, "5", "lower"), MimeType: "text/plain", ModTime: modTime},
+		{Name: "../../etc/passwd", Path: writeFile(t, dir, "6", "slip"), MimeType: "text/plain", ModTime: modTime},
+		{Name: "/abs/photo.jpg", Path: writeFile(t, dir, "7", "jpeg"), MimeType: "image/jpeg", ModTime: modTime},
+		{Name: "missing.txt", Path: filepath.Join(dir, "missing"), MimeType: "text/plain", ModTime: modTime},
+		{Name: "folder\\sub\\a.txt", Path: writeFile(t, dir, "8", "nested"), MimeType: "text/plain", ModTime: modTime},
+	}
+
+	var buf bytes.Buffer
+	skipped, err := Write(&buf, entries)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(skipped) != 1 || skipped[0] != "missing.txt" {
+		t.Errorf("skipped = %v, want [missing.txt]", skipped)
+	}
+
+	want := []struct {
+		name    string
+		content string
+		method  uint16
+	}{
+		{"보고서.txt", "first", zip.Deflate},
+		{"보고서 (2).txt", "second", zip.Deflate},
+		{"보고서 (2) (2).txt", "third", zip.Deflate},
+		{"REPORT.TXT", "upper", zip.Deflate},
+		{"report (2).txt", "lower", zip.Deflate},
+		{"etc/passwd", "slip", zip.Deflate},
+		{"abs/photo.jpg", "jpeg", zip.Store},
+		{"folder/sub/a.txt", "nested", zip.Deflate},
+	}
+	files := readZip(t, &buf)
+	if len(files) != len(want) {
+		t.Fatalf("zip has %d entries, want %d", len(files), len(want))
+	}
+	for i, f := range files {
+		if f.Name != want[i].name {
+			t.Errorf("entry %d name = %q, want %q", i, f.Name, want[i].name)
+		}
+		if got := readEntry(t, f); got != want[i].content {
+			t.Errorf("entry %q content = %q, want %q", f.Name, got, want[i].content)
+		}
+		if f.Method != want[i].method {
+			t.Errorf("entry %q method = %d, want %d", f.Name, f.Method, want[i].method)
+		}
+		if f.Flags&flagUTF8 == 0 {
+			t.Errorf("entry %q missing UTF-8 flag (flags %#x)", f.Name, f.Flags)
+		}
+		if !f.Modified.Equal(modTime) {
+			t.Errorf("entry %q modified = %v, want %v", f.Name, f.Modified, modTime)
+		}
+	}
+}
+
+func TestWriteEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	skipped, err := Write(&buf, nil)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if len(skipped) != 0 {
+		t.Errorf("skipped = %v, want none", skipped)
+	}
+	if files := readZip(t, &buf); len(files) != 0 {
+		t.Errorf("zip has %d entries, want 0", len(files))
+	}
+}
+
+func TestSanitizePath(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"a.txt", "a.txt"},
+		{"folder/a.txt", "folder/a.txt"},
+		{"folder\\a.txt", "folder/a.txt"},
+		{"../a.txt", "a.txt"},
+		{"../../../etc/passwd", "etc/passwd"},
+		{"folder/../../a.txt", "a.txt"},
+		{"folder/./sub//a.txt", "folder/sub/a.txt"},
+		{"/etc/passwd", "etc/passwd"},
+		{"\\\\server\\share\\a.txt", "server/share/a.txt"},
+		{"a\x00b\nc\x7f.txt", "abc.txt"},
+		{"..", "file"},
+		{"/", "file"},
+		{"", "file"},
+	}
+	for _, tt := range tests {
+		if got := SanitizePath(tt.input); got != tt.want {
+			t.Errorf("SanitizePath(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNameSetUnique(t *testing.T) {
+	names := newNameSet()
+	inputs := []string{"a.txt", "A.TXT", "a.txt", "a", "a", "dir/a.txt", "archive.tar.gz", "archive.tar.gz"}
+	want := []string{"a.txt", "A (2).TXT", "a (3).txt", "a", "a (2)", "dir/a.txt", "archive.tar.gz", "archive.tar (2).gz"}
+	for i, name := range inputs {
+		if got := names.unique(name); got != want[i] {
+			t.Errorf("unique(%q) #%d = %q, want %q", name, i, got, want[i])
+		}
+	}
+}
@@ -12,10 +12,18 @@ function getMaxFileSizeText() {
     return window.APP_CONFIG ? window.APP_CONFIG.maxFileSizeText : '500MB';
 }
 
+// 현재 게시판 (비어 있으면 서버에서 기본 게시판 사용)
+function getBoardSlug() {
+    return window.APP_CONFIG && window.APP_CONFIG.board ? window.APP_CONFIG.board : '';
+}
+
 // DOM이 로드되면 초기화
 document.addEventListener('DOMContentLoaded', function() {
-    initializeDragAndDrop();
-    initializeFileInput();
+    // 업로드 폼이 있는 게시판 페이지에서만 업로드 관련 초기화 (게시판 설정에 따라 폼이 하나만 있을 수 있음)
+    if (document.getElementById('fileUploadArea')) {
+        initializeDragAndDrop();
+        initializeFileInput();
+    }
     initializeForms();
     initializeContentToggle();
     updateAllDates();
@@ -64,11 +72,65 @@ function initializeDragAndDrop() {
     
     function handleFileDrop(e) {
         fileUploadArea.classList.remove('dragover');
+
+        // 폴더가 포함되어 있으면 하위 파일까지 상대 경로와 함께 수집
+        const entries = Array.from(e.dataTransfer.items || [])
+            .map(item => item.webkitGetAsEntry ? item.webkitGetAsEntry() : null)
+            .filter(entry => entry);
+        if (entries.some(entry => entry.isDirectory)) {
+            Promise.all(entries.map(entry => readEntry(entry)))
+                .then(results => handleFileSelection(results.flat()))
+                .catch(error => {
+                    console.error('폴더 읽기 실패:', error);
+                    showNotification('폴더를 읽는 중 오류가 발생했습니다.', 'error');
+                });
+            return;
+        }
+
         const files = Array.from(e.dataTransfer.files);
         handleFileSelection(files);
     }
 }
 
+// 드롭된 파일/폴더 항목을 재귀적으로 읽어 파일 목록 반환 (폴더 안 파일에는 relativePath 지정)
+function readEntry(entry) {
+    if (entry.isFile) {
+        return new Promise((resolve, reject) => {
+            entry.file(file => {
+                // 최상위에 드롭된 파일은 일반 파일로 처리
+                if (entry.fullPath.split('/').length > 2) {
+                    file.relativePath = entry.fullPath.replace(/^\//, '');
+                }
+                resolve([file]);
+            }, reject);
+        });
+    }
+
+    const reader = entry.createReader();
+    const children = [];
+    // readEntries는 한 번에 일부만 반환하므로 빈 배열이 나올 때까지 반복
+    return new Promise((resolve, reject) => {
+        const readBatch = () => {
+            reader.readEntries(batch => {
+                if (batch.length === 0) {
+                    Promise.all(children.map(child => readEntry(child)))
+                        .then(results => resolve(results.flat()))
+                        .catch(reject);
+                    return;
+                }
+                children.push(...batch);
+                readBatch();
+            }, reject);
+        };
+        readBatch();
+    });
+}
+
+// 폴더 업로드 파일의 상대 경로 (일반 파일은 빈 문자열)
+function getRelativePath(file) {
+    return file.relativePath || file.webkitRelativePath || '';
+}
+
 // 파일 입력 초기화
 function initializeFileInput() {
     const fileInput = document.getElementById('fileInput');
@@ -78,6 +140,11 @@ function initializeFileInput() {
     
     // 새 이벤트 리스너 추가
     fileInput.addEventListener('change', handleFileInputChange);
+
+    // 폴더 선택 입력 (파일마다 webkitRelativePath가 지정됨)
+    const folderInput = document.getElementById('folderInput');
+    folderInput.removeEventListener('change', handleFileInputChange);
+    folderInput.addEventListener('change', handleFileInputChange);
 }
 
 // 파일 입력 변경 핸들러 (분리하여 참조 가능하게 함)
@@ -105,9 +172,10 @@ function handleFileSelection(files) {
     const duplicateFiles = [];
     
     files.forEach(file => {
-        // 중복 파일 체크 (이름과 크기로 판단)
+        // 중복 파일 체크 (이름, 폴더 경로와 크기로 판단)
         const isDuplicate = selectedFiles.some(existingFile => 
-            existingFile.name === file.name && existingFile.size === file.size
+            existingFile.name === file.name && existingFile.size === file.size &&
+            getRelativePath(existingFile) === getRelativePath(file)
         );
         
         if (isDuplicate) {
@@ -151,7 +219,7 @@ function updateFileList() {
     fileList.innerHTML = selectedFiles.map((file, index) => `
         <div class="file-item">
             <div class="file-info">
-                <div class="file-name">${file.name}</div>
+                <div class="file-name">${escapeHTML(getRelativePath(file) || file.name)}</div>
                 <div class="file-size">${formatFileSize(file.size)}</div>
             </div>
             <button class="remove-file" onclick="removeFile(${index})">제거</button>
@@ -159,6 +227,13 @@ function updateFileList() {
     `).join('');
 }
 
+// HTML 특수 문자 이스케이프
+function escapeHTML(text) {
+    const div = document.createElement('div');
+    div.textContent = text;
+    return div.innerHTML;
+}
+
 // 파일 크기 포맷팅
 function formatFileSize(bytes) {
     if (bytes === 0) return '0 Bytes';
@@ -195,6 +270,7 @@ function resetFileUpload() {
     initializeFileInput();
     
     document.getElementById('fileTitle').value = '';
+    document.getElementById('fileTags').value = '';
     updateFileList();
     updateUploadButton();
     document.getElementById('fileUploadArea').classList.remove('dragover');
@@ -204,11 +280,15 @@ function resetFileUpload() {
 function initializeForms() {
     // 파일 업로드 폼
     const fileUploadForm = document.getElementById('fileUploadForm');
-    fileUploadForm.addEventListener('submit', handleFileUpload);
+    if (fileUploadForm) {
+        fileUploadForm.addEventListener('submit', handleFileUpload);
+    }
     
     // 메시지 업로드 폼
     const messageUploadForm = document.getElementById('messageUploadForm');
-    messageUploadForm.addEventListener('submit', handleMessageUpload);
+    if (messageUploadForm) {
+        messageUploadForm.addEventListener('submit', handleMessageUpload);
+    }
 }
 
 // 파일 업로드 처리
@@ -229,38 +309,19 @@ async function handleFileUpload(e) {
     showLoadingOverlay(true);
     
     const title = document.getElementById('fileTitle').value;
-    let successCount = 0;
-    let errorCount = 0;
+    const tags = document.getElementById('fileTags').value;
     
     try {
-        // 각 파일을 순차적으로 업로드
-        for (const file of selectedFiles) {
-            try {
-                await uploadSingleFile(file, title);
-                successCount++;
-            } catch (error) {
-                console.error('파일 업로드 실패:', error);
-                errorCount++;
-            }
-        }
-        
-        // 결과 알림
-        if (successCount > 0 && errorCount === 0) {
-            showNotification(`${successCount}개 파일이 업로드되었습니다.`, 'success');
-            resetFileUpload();
-            isReloading = true; // 새로고침 플래그 설정
-            location.reload();
-        } else if (successCount > 0 && errorCount > 0) {
-            showNotification(`${successCount}개 성공, ${errorCount}개 실패`, 'info');
-            resetFileUpload();
-        } else {
-            showNotification('파일 업로드에 실패했습니다.', 'error');
-        }
+        // 선택한 파일들을 하나의 게시글로 업로드
+        await uploadFiles(selectedFiles, title, tags);
+        showNotification(`${selectedFiles.length}개 파일이 업로드되었습니다.`, 'success');
+        resetFileUpload();
+        isReloading = true; // 새로고침 플래그 설정
+        location.reload();
         
     } catch (error) {
         console.error('업로드 중 오류:', error);
-        showNotification('업로드 중 오류가 발생했습니다.', 'error');
-        resetFileUpload(); // 오류 발생시에도 파일 입력 초기화
+        showNotification(error.message || '업로드 중 오류가 발생했습니다.', 'error');
     } finally {
         uploadInProgress = false;
         showLoadingOverlay(false);
@@ -268,12 +329,18 @@ async function handleFileUpload(e) {
     }
 }
 
-// 단일 파일 업로드
-function uploadSingleFile(file, title) {
+// 여러 파일을 하나의 게시글로 업로드 (제목이 비어 있으면 서버에서 파일명으로 설정)
+function uploadFiles(files, title, tags) {
     return new Promise((resolve, reject) => {
         const formData = new FormData();
-        formData.append('file', file);
-        formData.append('title', title || file.name);
+        files.forEach(file => formData.append('file', file));
+        // 폴더가 포함되어 있으면 모든 파일의 경로를 같은 순서로 전달
+        if (files.some(file => getRelativePath(file))) {
+            files.forEach(file => formData.append('path', getRelativePath(file) || file.name));
+        }
+        formData.append('title', title);
+        formData.append('tags', tags || '');
+        formData.append('board', getBoardSlug());
         
         fetch('/upload/file', {
             method: 'POST',
@@ -318,6 +385,8 @@ function handleMessageUpload(e) {
     const formData = new FormData();
     formData.append('title', title);
     formData.append('content', content);
+    formData.append('tags', document.getElementById('messageTags').value);
+    formData.append('board', getBoardSlug());
     
     fetch('/upload/message', {
         method: 'POST',
@@ -344,6 +413,43 @@ function handleMessageUpload(e) {
     });
 }
 
+// 메시지 마크다운 미리보기 토글
+function toggleMessagePreview() {
+    const textarea = document.getElementById('messageContent');
+    const preview = document.getElementById('messagePreview');
+    const button = document.getElementById('messagePreviewBtn');
+
+    // 미리보기 중이면 편집 모드로 복귀
+    if (preview.style.display === 'block') {
+        preview.style.display = 'none';
+        textarea.style.display = '';
+        button.textContent = '미리보기';
+        return;
+    }
+
+    fetch('/preview/markdown', {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ content: textarea.value })
+    })
+    .then(response => response.json())
+    .then(data => {
+        if (data.html === undefined) {
+            showNotification(data.error || '미리보기에 실패했습니다.', 'error');
+            return;
+        }
+        // 서버에서 sanitize된 HTML
+        preview.innerHTML = data.html || '<p class="upload-hint">내용이 없습니다.</p>';
+        preview.style.display = 'block';
+        textarea.style.display = 'none';
+        button.textContent = '편집';
+    })
+    .catch(error => {
+        console.error('미리보기 실패:', error);
+        showNotification('미리보기 중 오류가 발생했습니다.', 'error');
+    });
+}
+
 // 내용 토글 초기화
 function initializeContentToggle() {
     // 페이지 로드시 모든 내용 숨기기
@@ -368,6 +474,213 @@ function toggleContent(postId) {
     }
 }
 
+// 댓글 영역 토글 (처음 열 때 목록 로드)
+function toggleComments(postId) {
+    const container = document.getElementById(`comments-${postId}`);
+    if (!container) return;
+
+    if (container.style.display === 'block') {
+        container.style.display = 'none';
+        return;
+    }
+    container.style.display = 'block';
+    loadComments(postId);
+}
+
+// 댓글 목록 로드
+function loadComments(postId) {
+    const container = document.getElementById(`comments-${postId}`);
+
+    fetch(`/posts/${postId}/comments`)
+        .then(response => response.json())
+        .then(data => {
+            if (!data.comments) {
+                showNotification(data.error || '댓글을 불러올 수 없습니다.', 'error');
+                return;
+            }
+            renderComments(postId, container, data.comments);
+        })
+        .catch(error => {
+            console.error('댓글 로드 실패:', error);
+            showNotification('댓글을 불러오는 중 오류가 발생했습니다.', 'error');
+        });
+}
+
+// 댓글 트리 렌더링 (관리자 페이지에서는 삭제/복구 버튼 표시, 사용자 페이지에서는 작성 폼 표시)
+function renderComments(postId, container, comments) {
+    container.innerHTML = '';
+
+    const list = document.createElement('div');
+    list.className = 'comment-list';
+    comments.forEach(comment => list.appendChild(renderComment(postId, comment)));
+    if (comments.length === 0) {
+        list.innerHTML = '<p class="upload-hint">아직 댓글이 없습니다.</p>';
+    }
+    container.appendChild(list);
+
+    if (!window.COMMENT_ADMIN) {
+        container.appendChild(createCommentForm(postId, 0));
+    }
+}
+
+// 댓글 하나와 답글 렌더링 (내용은 textContent로만 삽입)
+function renderComment(postId, comment) {
+    const item = document.createElement('div');
+    item.className = 'comment-item' + (comment.deleted ? ' deleted-comment' : '');
+    item.id = `comment-${comment.id}`;
+
+    const meta = document.createElement('div');
+    meta.className = 'comment-meta';
+    const date = document.createElement('span');
+    date.className = 'post-date';
+    date.textContent = timeAgo(comment.created_at);
+    date.title = new Date(comment.created_at).toLocaleString('ko-KR');
+    meta.appendChild(date);
+
+    if (comment.ip_address) {
+        const ip = document.createElement('span');
+        ip.className = 'post-ip';
+        ip.textContent = `IP: ${comment.ip_address}`;
+        meta.appendChild(ip);
+    }
+
+    const body = document.createElement('div');
+    body.className = 'comment-content';
+    if (comment.deleted && !window.COMMENT_ADMIN) {
+        body.textContent = '삭제된 댓글입니다.';
+    } else {
+        body.textContent = comment.content;
+    }
+
+    const actions = document.createElement('div');
+    actions.className = 'comment-actions';
+    if (window.COMMENT_ADMIN) {
+        const button = document.createElement('button');
+        button.className = comment.deleted ? 'restore-btn' : 'delete-btn';
+        button.textContent = comment.deleted ? '♻️' : '🗑️';
+        button.title = comment.deleted ? '복구' : '삭제';
+        button.onclick = () => setCommentDeleted(comment.id, postId, !comment.deleted);
+        actions.appendChild(button);
+    } else if (!comment.deleted) {
+        const button = document.createElement('button');
+        button.className = 'reply-btn';
+        button.textContent = '답글';
+        button.onclick = () => toggleReplyForm(postId, comment.id);
+        actions.appendChild(button);
+    }
+
+    item.appendChild(meta);
+    item.appendChild(body);
+    item.appendChild(actions);
+
+    const replies = document.createElement('div');
+    replies.className = 'comment-replies';
+    replies.id = `replies-${comment.id}`;
+    (comment.replies || []).forEach(reply => replies.appendChild(renderComment(postId, reply)));
+    item.appendChild(replies);
+
+    return item;
+}
+
+// 댓글/답글 작성 폼 생성
+function createCommentForm(postId, parentId) {
+    const form = document.createElement('form');
+    form.className = 'comment-form';
+    if (parentId) {
+        form.id = `reply-form-${parentId}`;
+    }
+
+    const textarea = document.createElement('textarea');
+    textarea.rows = 2;
+    textarea.placeholder = parentId ? '답글을 입력하세요' : '댓글을 입력하세요';
+    textarea.required = true;
+
+    const button = document.createElement('button');
+    button.type = 'submit';
+    button.className = 'upload-btn';
+    button.textContent = parentId ? '답글 등록' : '댓글 등록';
+
+    form.appendChild(textarea);
+    form.appendChild(button);
+    form.addEventListener('submit', e => {
+        e.preventDefault();
+        submitComment(postId, parentId, textarea, button);
+    });
+    return form;
+}
+
+// 답글 작성 폼 토글
+function toggleReplyForm(postId, commentId) {
+    const existing = document.getElementById(`reply-form-${commentId}`);
+    if (existing) {
+        existing.remove();
+        return;
+    }
+
+    const replies = document.getElementById(`replies-${commentId}`);
+    const form = createCommentForm(postId, commentId);
+    replies.parentNode.insertBefore(form, replies);
+    form.querySelector('textarea').focus();
+}
+
+// 댓글 등록
+function submitComment(postId, parentId, textarea, button) {
+    const content = textarea.value.trim();
+    if (!content) {
+        showNotification('댓글 내용을 입력해주세요.', 'error');
+        return;
+    }
+
+    button.disabled = true;
+
+    fetch(`/posts/${postId}/comments`, {
+        method: 'POST',
+        headers: { 'Content-Type': 'application/json' },
+        body: JSON.stringify({ content: content, parent_id: parentId })
+    })
+    .then(response => response.json())
+    .then(data => {
+        if (data.message) {
+            showNotification(data.message, 'success');
+            const count = document.getElementById(`comment-count-${postId}`);
+            if (count) count.textContent = parseInt(count.textContent, 10) + 1;
+            loadComments(postId);
+        } else {
+            showNotification(data.error || '댓글 등록에 실패했습니다.', 'error');
+        }
+    })
+    .catch(error => {
+        console.error('댓글 등록 실패:', error);
+        showNotification('댓글 등록 중 오류가 발생했습니다.', 'error');
+    })
+    .finally(() => {
+        button.disabled = false;
+    });
+}
+
+// ZIP 다운로드 선택 상태 갱신
+function updateArchiveSelection() {
+    const count = document.querySelectorAll('.post-select:checked').length;
+    const button = document.getElementById('archiveSelectedBtn');
+    const label = document.getElementById('archiveSelectedCount');
+    if (button) button.disabled = count === 0;
+    if (label) label.textContent = count > 0 ? `${count}개 선택됨` : '';
+}
+
+// 선택한 게시글들의 파일을 ZIP으로 다운로드
+function downloadSelectedPosts() {
+    const ids = Array.from(document.querySelectorAll('.post-select:checked')).map(el => el.value);
+    if (ids.length === 0) {
+        showNotification('다운로드할 게시글을 선택해주세요.', 'error');
+        return;
+    }
+    if (ids.length > 100) {
+        showNotification('한 번에 최대 100개 게시글까지 다운로드할 수 있습니다.', 'error');
+        return;
+    }
+    window.location.href = `/archive?ids=${ids.join(',')}`;
+}
+
 // 로딩 오버레이 표시/숨기기
 function showLoadingOverlay(show) {
     const overlay = document.getElementById('loadingOverlay');